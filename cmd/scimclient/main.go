@@ -0,0 +1,187 @@
+// Command scimclient is a local SCIM 2.0 client harness: it replays the calls an identity
+// provider makes when provisioning joiners and leavers against a running service and checks
+// the responses, including that SCIM deactivation is visible through /users/get.
+//
+//	go run ./cmd/scimclient -url http://localhost:8080 -token "$SCIM_TOKEN"
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+const patchOp = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+
+type client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+	failed  int
+}
+
+func main() {
+	baseURL := flag.String("url", "http://localhost:8080", "service base URL")
+	token := flag.String("token", os.Getenv("SCIM_TOKEN"), "SCIM bearer token")
+	flag.Parse()
+
+	c := &client{baseURL: *baseURL, token: *token, http: &http.Client{Timeout: 10 * time.Second}}
+	suffix := strconv.FormatInt(time.Now().Unix(), 36)
+	alice, bob, team := "scim-alice-"+suffix, "scim-bob-"+suffix, "scim-team-"+suffix
+
+	c.expect("service provider config", http.MethodGet, "/scim/v2/ServiceProviderConfig", nil, http.StatusOK, nil)
+
+	for _, userName := range []string{alice, bob} {
+		c.expect("create user "+userName, http.MethodPost, "/scim/v2/Users", map[string]any{
+			"schemas":  []string{"urn:ietf:params:scim:schemas:core:2.0:User"},
+			"userName": userName,
+			"name":     map[string]string{"givenName": "Test", "familyName": userName},
+			"active":   true,
+		}, http.StatusCreated, func(body map[string]any) error {
+			return field(body, "active", true)
+		})
+	}
+	c.expect("reject duplicate user", http.MethodPost, "/scim/v2/Users", map[string]any{"userName": alice}, http.StatusConflict, nil)
+
+	c.expect("filter users by userName", http.MethodGet, "/scim/v2/Users?filter="+url.QueryEscape(`userName eq "`+alice+`"`), nil,
+		http.StatusOK, func(body map[string]any) error {
+			return field(body, "totalResults", float64(1))
+		})
+
+	c.expect("create group", http.MethodPost, "/scim/v2/Groups", map[string]any{
+		"displayName": team,
+		"members":     []map[string]string{{"value": alice}},
+	}, http.StatusCreated, members(1))
+
+	c.expect("add member", http.MethodPatch, "/scim/v2/Groups/"+team, map[string]any{
+		"schemas":    []string{patchOp},
+		"Operations": []map[string]any{{"op": "add", "path": "members", "value": []map[string]string{{"value": bob}}}},
+	}, http.StatusOK, members(2))
+
+	c.expect("remove member", http.MethodPatch, "/scim/v2/Groups/"+team, map[string]any{
+		"schemas":    []string{patchOp},
+		"Operations": []map[string]any{{"op": "remove", "path": `members[value eq "` + alice + `"]`}},
+	}, http.StatusOK, members(1))
+
+	// Some identity providers send booleans as strings.
+	c.expect("deactivate user", http.MethodPatch, "/scim/v2/Users/"+bob, map[string]any{
+		"schemas":    []string{patchOp},
+		"Operations": []map[string]any{{"op": "replace", "path": "active", "value": "False"}},
+	}, http.StatusOK, func(body map[string]any) error {
+		return field(body, "active", false)
+	})
+	c.expect("deactivation visible in REST API", http.MethodGet, "/users/get?user_id="+bob, nil, http.StatusOK, func(body map[string]any) error {
+		return field(body, "is_active", false)
+	})
+
+	c.expect("replace user", http.MethodPut, "/scim/v2/Users/"+bob, map[string]any{
+		"userName":    bob,
+		"displayName": "Bob Renamed",
+		"active":      true,
+	}, http.StatusOK, func(body map[string]any) error {
+		if err := field(body, "displayName", "Bob Renamed"); err != nil {
+			return err
+		}
+		return field(body, "active", true)
+	})
+
+	c.expect("delete user offboards", http.MethodDelete, "/scim/v2/Users/"+alice, nil, http.StatusNoContent, nil)
+	c.expect("deleted user is gone", http.MethodGet, "/scim/v2/Users/"+alice, nil, http.StatusNotFound, nil)
+	c.expect("deleted user ID is not reused", http.MethodPost, "/scim/v2/Users", map[string]any{"userName": alice}, http.StatusConflict, nil)
+	c.expect("history kept as tombstone", http.MethodGet, "/users/get?user_id="+alice, nil, http.StatusOK, func(body map[string]any) error {
+		if body["deleted_at"] == nil {
+			return fmt.Errorf("deleted_at is not set")
+		}
+		return nil
+	})
+
+	c.expect("delete group", http.MethodDelete, "/scim/v2/Groups/"+team, nil, http.StatusNoContent, nil)
+	c.expect("deleted group is gone", http.MethodGet, "/scim/v2/Groups/"+team, nil, http.StatusNotFound, nil)
+
+	if c.failed > 0 {
+		fmt.Printf("%d check(s) failed\n", c.failed)
+		os.Exit(1)
+	}
+	fmt.Println("all checks passed")
+}
+
+// expect performs one request and verifies the status code and, optionally, the JSON body.
+func (c *client) expect(name, method, path string, payload any, status int, check func(map[string]any) error) {
+	err := c.do(method, path, payload, status, check)
+	if err != nil {
+		c.failed++
+		fmt.Printf("FAIL %s: %v\n", name, err)
+		return
+	}
+	fmt.Printf("ok   %s\n", name)
+}
+
+func (c *client) do(method, path string, payload any, status int, check func(map[string]any) error) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/scim+json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != status {
+		return fmt.Errorf("status %d, want %d: %s", resp.StatusCode, status, bytes.TrimSpace(data))
+	}
+	if check == nil {
+		return nil
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	if user, ok := decoded["user"].(map[string]any); ok {
+		decoded = user
+	}
+	return check(decoded)
+}
+
+func field(body map[string]any, name string, want any) error {
+	if got := body[name]; got != want {
+		return fmt.Errorf("%s = %v, want %v", name, got, want)
+	}
+	return nil
+}
+
+func members(want int) func(map[string]any) error {
+	return func(body map[string]any) error {
+		list, _ := body["members"].([]any)
+		if len(list) != want {
+			return fmt.Errorf("%d members, want %d", len(list), want)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"pr-review-service/internal/config"
+	"pr-review-service/internal/database"
+	"pr-review-service/internal/orgimport"
+)
+
+// runImport implements `server import [-format csv|yaml] [-dry-run] <file>`: it prints the diff
+// against the current tables and, unless -dry-run is set, applies it atomically.
+func runImport(cfg *config.Config, args []string) int {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	format := fs.String("format", "", "org chart format: csv or yaml (default: from file extension)")
+	dryRun := fs.Bool("dry-run", false, "only print the diff, do not apply it")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: server import [-format csv|yaml] [-dry-run] <file>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = orgimport.FormatFromPath(path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import: %v\n", err)
+		return 1
+	}
+	defer file.Close()
+
+	chart, err := orgimport.Parse(file, *format)
+	if err != nil {
+		return reportImportError(err)
+	}
+
+	db, err := database.New(cfg.DatabaseURL())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import: failed to connect to database: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	diff, err := orgimport.Import(context.Background(), db, chart, *dryRun)
+	if err != nil {
+		return reportImportError(err)
+	}
+
+	orgimport.WriteDiff(os.Stdout, diff)
+	if *dryRun {
+		fmt.Println("dry run: nothing applied")
+	} else {
+		fmt.Println("applied")
+	}
+	return 0
+}
+
+func reportImportError(err error) int {
+	var validationErr *orgimport.ValidationError
+	if errors.As(err, &validationErr) {
+		fmt.Fprintln(os.Stderr, "import: org chart is invalid:")
+		for _, problem := range validationErr.Problems {
+			fmt.Fprintf(os.Stderr, "  - %s\n", problem)
+		}
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "import: %v\n", err)
+	return 1
+}
@@ -1,19 +1,52 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net"
+	"os"
 
 	"pr-review-service/internal/config"
 	"pr-review-service/internal/database"
+	"pr-review-service/internal/events"
+	"pr-review-service/internal/graph"
+	"pr-review-service/internal/grpcapi"
 	"pr-review-service/internal/handlers"
+	"pr-review-service/internal/notify"
+	"pr-review-service/internal/scim"
 	"pr-review-service/internal/server"
+	"pr-review-service/internal/worker"
 )
 
 func main() {
-	cfg := config.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(runImport(cfg, os.Args[2:]))
+	}
+
+	workerCfg := worker.Config{
+		Interval:       cfg.WorkerInterval,
+		ReminderAfter:  cfg.ReminderAfter,
+		EscalateAfter:  cfg.EscalateAfter,
+		EscalationMode: cfg.EscalationMode,
+	}
+	if cfg.WorkerEnabled {
+		if err := workerCfg.Validate(); err != nil {
+			log.Fatalf("Invalid worker configuration: %v", err)
+		}
+	}
+
 	log.Printf("Starting PR Review Service...")
 	log.Printf("Database: %s:%s/%s", cfg.DBHost, cfg.DBPort, cfg.DBName)
 	log.Printf("Server port: %s", cfg.Port)
+	if cfg.GRPCPort != "" {
+		log.Printf("gRPC port: %s", cfg.GRPCPort)
+	} else {
+		log.Printf("gRPC API disabled: GRPC_PORT is empty")
+	}
 
 	db, err := database.New(cfg.DatabaseURL())
 	if err != nil {
@@ -21,9 +54,49 @@ func main() {
 	}
 	defer db.Close()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if cfg.WorkerEnabled {
+		w := worker.New(db, notify.New(cfg.NotifyWebhookURL), workerCfg)
+		go w.Run(ctx)
+	}
+	retention := worker.NewRetention(db, worker.RetentionConfig{
+		Interval:        cfg.RetentionInterval,
+		EventRetention:  cfg.EventRetention,
+		ChangeRetention: cfg.ChangeRetention,
+	})
+	go retention.Run(ctx)
+
+	if cfg.GRPCPort != "" {
+		lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+		if err != nil {
+			log.Fatalf("Failed to listen on gRPC port: %v", err)
+		}
+		grpcServer := grpcapi.NewGRPCServer(db)
+		defer grpcServer.GracefulStop()
+		go func() {
+			log.Printf("gRPC server listening on port %s", cfg.GRPCPort)
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Fatalf("gRPC server failed: %v", err)
+			}
+		}()
+	}
+
 	h := handlers.New(db)
 
 	srv := server.New(h)
+	if cfg.SCIMToken != "" {
+		srv.Mount(scim.BasePath+"/", scim.New(db, cfg.SCIMToken))
+	} else {
+		log.Printf("SCIM provisioning disabled: SCIM_TOKEN is not set")
+	}
+	srv.Mount(graph.Path, graph.New(db))
+
+	broker := events.New(db)
+	go broker.Run(ctx)
+	srv.Mount(events.Path, broker)
+	srv.EnableIdempotency(cfg.IdempotencyTTL)
 
 	if err := srv.Start(cfg.Port); err != nil {
 		log.Fatalf("Server failed to start: %v", err)
@@ -1,5 +1,18 @@
 module pr-review-service
 
-go 1.24
+go 1.24.0
 
-require github.com/lib/pq v1.10.9
+require (
+	github.com/graph-gophers/graphql-go v1.9.0
+	github.com/lib/pq v1.10.9
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260120221211-b8f7ae30c516
+	google.golang.org/grpc v1.80.0
+	google.golang.org/protobuf v1.36.11
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	golang.org/x/net v0.49.0 // indirect
+	golang.org/x/sys v0.40.0 // indirect
+	golang.org/x/text v0.33.0 // indirect
+)
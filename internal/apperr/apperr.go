@@ -0,0 +1,66 @@
+// Package apperr defines the domain errors returned by the database layer. Each error carries
+// one of the models.Err* codes; handlers map the code to an HTTP status, and anything that is
+// not an *Error is treated as an infrastructure failure.
+package apperr
+
+import "pr-review-service/internal/models"
+
+// Error is a domain error: a request that was understood but cannot be carried out.
+type Error struct {
+	Code    string
+	Message string
+	// Fields identify the entities involved, e.g. pull_request_id, and are exposed to clients
+	// as problem details extension members.
+	Fields map[string]string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Message
+}
+
+// Is matches by code, so errors.Is(err, apperr.ErrNotFound) holds for any NOT_FOUND error.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+var (
+	ErrNotFound           = &Error{Code: models.ErrNotFound, Message: "resource not found"}
+	ErrTeamExists         = &Error{Code: models.ErrTeamExists, Message: "team_name already exists"}
+	ErrPRExists           = &Error{Code: models.ErrPRExists, Message: "PR id already exists"}
+	ErrPRMerged           = &Error{Code: models.ErrPRMerged, Message: "cannot reassign on merged PR"}
+	ErrNotAssigned        = &Error{Code: models.ErrNotAssigned, Message: "reviewer is not assigned to this PR"}
+	ErrNoCandidate        = &Error{Code: models.ErrNoCandidate, Message: "no active replacement candidate in team"}
+	ErrRepositoryExists   = &Error{Code: models.ErrRepositoryExists, Message: "repository_name already exists"}
+	ErrParentNotMerged    = &Error{Code: models.ErrParentNotMerged, Message: "parent PR must be merged first"}
+	ErrTeamHasOpenReviews = &Error{Code: models.ErrTeamHasOpenReviews, Message: "team members still review OPEN PRs; pass force to delete anyway"}
+	ErrTeamHasRepos       = &Error{Code: models.ErrTeamHasRepos, Message: "team owns repositories; move them to another team first"}
+	ErrTeamCycle          = &Error{Code: models.ErrTeamCycle, Message: "team cannot be nested under itself or its sub-team"}
+	ErrUserExists         = &Error{Code: models.ErrUserExists, Message: "user_id already exists"}
+	ErrUserDeleted        = &Error{Code: models.ErrUserDeleted, Message: "user has been offboarded"}
+	ErrVersionMismatch    = &Error{Code: models.ErrPreconditionFailed, Message: "PR has been modified since it was read"}
+	ErrCursorExpired      = &Error{Code: models.ErrCursorExpired, Message: "changes after the cursor have been purged; resync from a full dump"}
+)
+
+// With returns a copy of e that also carries key=value.
+func (e *Error) With(key, value string) *Error {
+	fields := make(map[string]string, len(e.Fields)+1)
+	for k, v := range e.Fields {
+		fields[k] = v
+	}
+	fields[key] = value
+	return &Error{Code: e.Code, Message: e.Message, Fields: fields}
+}
+
+// New returns an error with the code of sentinel and a more specific message.
+func New(sentinel *Error, message string) *Error {
+	return &Error{Code: sentinel.Code, Message: message}
+}
+
+// NotFound names the missing entity, e.g. NotFound("team") reads "team not found".
+func NotFound(entity string) *Error {
+	return New(ErrNotFound, entity+" not found")
+}
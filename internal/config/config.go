@@ -1,8 +1,11 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
@@ -13,10 +16,31 @@ type Config struct {
 	DBName   string
 	Port     string
 	LogLevel string
+
+	WorkerEnabled    bool
+	WorkerInterval   time.Duration
+	ReminderAfter    time.Duration
+	EscalateAfter    time.Duration
+	EscalationMode   string
+	NotifyWebhookURL string
+
+	SCIMToken string
+
+	// GRPCPort is empty when gRPC is disabled.
+	GRPCPort string
+
+	IdempotencyTTL time.Duration
+
+	RetentionInterval time.Duration
+	EventRetention    time.Duration
+	ChangeRetention   time.Duration
 }
 
-func Load() *Config {
-	return &Config{
+// Load reads the configuration from the environment. Unset variables take their defaults; a
+// duration or boolean that is set but malformed or out of range is an error rather than silently replaced.
+func Load() (*Config, error) {
+	var errs []error
+	cfg := &Config{
 		DBHost:   getEnv("DB_HOST", "localhost"),
 		DBPort:   getEnv("DB_PORT", "5432"),
 		DBUser:   getEnv("DB_USER", "prservice_user"),
@@ -24,7 +48,25 @@ func Load() *Config {
 		DBName:   getEnv("DB_NAME", "prservice"),
 		Port:     getEnv("SERVER_PORT", "8080"),
 		LogLevel: getEnv("LOG_LEVEL", "info"),
+
+		WorkerEnabled:    getBoolEnv(&errs, "WORKER_ENABLED", true),
+		WorkerInterval:   getDurationEnv(&errs, "WORKER_INTERVAL", time.Minute),
+		ReminderAfter:    getDurationEnv(&errs, "REMINDER_AFTER", 24*time.Hour),
+		EscalateAfter:    getDurationEnv(&errs, "ESCALATE_AFTER", 48*time.Hour),
+		EscalationMode:   getEnv("ESCALATION_MODE", "reassign"),
+		NotifyWebhookURL: getEnv("NOTIFY_WEBHOOK_URL", ""),
+
+		SCIMToken: getEnv("SCIM_TOKEN", ""),
+
+		GRPCPort: getOptionalEnv("GRPC_PORT", "9090"),
+
+		IdempotencyTTL: getTTLEnv(&errs, "IDEMPOTENCY_TTL", 24*time.Hour),
+
+		RetentionInterval: getDurationEnv(&errs, "RETENTION_INTERVAL", 10*time.Minute),
+		EventRetention:    getDurationEnv(&errs, "EVENT_RETENTION", 7*24*time.Hour),
+		ChangeRetention:   getDurationEnv(&errs, "CHANGE_RETENTION", 30*24*time.Hour),
 	}
+	return cfg, errors.Join(errs...)
 }
 
 func (c *Config) DatabaseURL() string {
@@ -38,3 +80,53 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getOptionalEnv is getEnv for settings that are turned off by an explicitly empty value.
+func getOptionalEnv(key, defaultValue string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// getBoolEnv reads a boolean such as "true" or "0"; a bad value is appended to errs.
+func getBoolEnv(errs *[]error, key string, defaultValue bool) bool {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%s must be true or false, got %q", key, value))
+		return defaultValue
+	}
+	return b
+}
+
+// getDurationEnv reads a positive duration such as "90m"; a bad value is appended to errs.
+func getDurationEnv(errs *[]error, key string, defaultValue time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		*errs = append(*errs, fmt.Errorf("%s must be a positive duration such as 30m or 24h, got %q", key, value))
+		return defaultValue
+	}
+	return d
+}
+
+// getTTLEnv is getDurationEnv for settings where 0 turns the feature off.
+func getTTLEnv(errs *[]error, key string, defaultValue time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		*errs = append(*errs, fmt.Errorf("%s must be 0 or a positive duration such as 24h, got %q", key, value))
+		return defaultValue
+	}
+	return d
+}
@@ -0,0 +1,30 @@
+package config
+
+import "testing"
+
+func TestGetBoolEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+		err   bool
+	}{
+		{value: "", want: true},
+		{value: "true", want: true},
+		{value: "false", want: false},
+		{value: "0", want: false},
+		{value: "yes", want: true, err: true},
+		{value: "off", want: true, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("TEST_BOOL", tt.value)
+			var errs []error
+			if got := getBoolEnv(&errs, "TEST_BOOL", true); got != tt.want {
+				t.Fatalf("want %v, got %v", tt.want, got)
+			}
+			if gotErr := len(errs) > 0; gotErr != tt.err {
+				t.Fatalf("want error %v, got %v", tt.err, errs)
+			}
+		})
+	}
+}
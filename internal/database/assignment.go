@@ -0,0 +1,135 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"math/rand/v2"
+	"sort"
+
+	"pr-review-service/internal/models"
+)
+
+type candidate struct {
+	userID string
+	// load counts the user's reviews on OPEN PRs, batchLoad those assigned earlier in the
+	// current batch (see CreateBatchPR).
+	load      int
+	batchLoad int
+}
+
+// availableReviewer restricts hotfix PRs ($3) to reviewers with no unanswered open reviews. There
+// is deliberately no fallback to busy reviewers: a hotfix gets fewer (or no) reviewers instead.
+const availableReviewer = `
+	($3 <> 'hotfix' OR NOT EXISTS (
+		SELECT 1 FROM pr_reviewers busy
+		JOIN pull_requests open_pr ON open_pr.pull_request_id = busy.pull_request_id
+		WHERE busy.user_id = users.user_id AND open_pr.status = 'OPEN' AND busy.responded_at IS NULL
+	))`
+
+func loadCandidates(ctx context.Context, tx *sql.Tx, teamName, authorID, priority string, includeSubteams bool) ([]candidate, error) {
+	rows, err := tx.QueryContext(ctx, teamScope("$1", "$4")+`
+		SELECT users.user_id, (
+			SELECT COUNT(*) FROM pr_reviewers l
+			JOIN pull_requests lp ON lp.pull_request_id = l.pull_request_id
+			WHERE l.user_id = users.user_id AND lp.status = 'OPEN'
+		)
+		FROM users
+		WHERE EXISTS(
+			SELECT 1 FROM team_memberships m
+			WHERE m.user_id = users.user_id AND m.team_name IN (SELECT team_name FROM scope)
+			  AND m.role <> 'observer'
+		)
+		AND users.is_active = true AND users.user_id != $2 AND `+availableReviewer, teamName, authorID, priority, includeSubteams)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := []candidate{}
+	for rows.Next() {
+		var c candidate
+		if err := rows.Scan(&c.userID, &c.load); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+// loadCandidateLevels returns candidates from the team first, then from each ancestor's
+// whole subtree ("my sub-team, else my department"), until enough candidates are found.
+func loadCandidateLevels(ctx context.Context, tx *sql.Tx, teamName, authorID, priority string, need int, exclude []string) ([][]candidate, error) {
+	seen := make(map[string]bool, len(exclude))
+	for _, userID := range exclude {
+		seen[userID] = true
+	}
+
+	scopes, err := teamAncestors(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := [][]candidate{}
+	found := 0
+	for i, scope := range append([]string{teamName}, scopes...) {
+		if found >= need {
+			break
+		}
+
+		candidates, err := loadCandidates(ctx, tx, scope, authorID, priority, i > 0)
+		if err != nil {
+			return nil, err
+		}
+
+		level := []candidate{}
+		for _, c := range candidates {
+			if !seen[c.userID] {
+				seen[c.userID] = true
+				level = append(level, c)
+			}
+		}
+		levels = append(levels, level)
+		found += len(level)
+	}
+
+	return levels, nil
+}
+
+func selectFromLevels(levels [][]candidate, max int, strategy string) []string {
+	selected := []string{}
+	for _, level := range levels {
+		if len(selected) >= max {
+			break
+		}
+		selected = append(selected, selectReviewers(level, max-len(selected), strategy)...)
+	}
+	return selected
+}
+
+func selectReviewers(candidates []candidate, max int, strategy string) []string {
+	shuffled := make([]candidate, len(candidates))
+	for i, j := range rand.Perm(len(candidates)) {
+		shuffled[i] = candidates[j]
+	}
+
+	if strategy == models.StrategyLeastLoaded {
+		sort.SliceStable(shuffled, func(i, j int) bool {
+			return shuffled[i].load < shuffled[j].load
+		})
+	} else {
+		// Still random within a batch, but among the users picked least often in it so far.
+		sort.SliceStable(shuffled, func(i, j int) bool {
+			return shuffled[i].batchLoad < shuffled[j].batchLoad
+		})
+	}
+
+	if len(shuffled) > max {
+		shuffled = shuffled[:max]
+	}
+
+	selected := make([]string, len(shuffled))
+	for i, c := range shuffled {
+		selected[i] = c.userID
+	}
+	return selected
+}
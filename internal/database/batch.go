@@ -0,0 +1,241 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"pr-review-service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// The *By* methods below load rows for many keys in a single query; they back the GraphQL
+// dataloaders. Keys without rows are absent from the result rather than reported as NOT_FOUND,
+// and offboarded users are included so that PR authors and reviewers always resolve.
+
+func (db *DB) UsersByID(ctx context.Context, userIDs []string) (map[string]*models.User, error) {
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT u.user_id, u.username, u.is_active, u.deleted_at,
+		       COALESCE((SELECT team_name FROM team_memberships WHERE user_id = u.user_id AND is_primary), ''),
+		       ARRAY(SELECT team_name FROM team_memberships WHERE user_id = u.user_id ORDER BY is_primary DESC, team_name)
+		FROM users u
+		WHERE u.user_id = ANY($1)
+	`, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make(map[string]*models.User, len(userIDs))
+	for rows.Next() {
+		user := &models.User{Teams: []string{}}
+		if err := rows.Scan(&user.UserID, &user.Username, &user.IsActive, &user.DeletedAt, &user.TeamName, pq.Array(&user.Teams)); err != nil {
+			return nil, err
+		}
+		users[user.UserID] = user
+	}
+	return users, rows.Err()
+}
+
+// TeamsByName loads teams with their direct members; members of sub-teams are not included.
+func (db *DB) TeamsByName(ctx context.Context, teamNames []string) (map[string]*models.Team, error) {
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT team_name, parent_team_name, review_sla_hours FROM teams WHERE team_name = ANY($1)
+	`, pq.Array(teamNames))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teams := make(map[string]*models.Team, len(teamNames))
+	for rows.Next() {
+		var parentTeamName sql.NullString
+		var reviewSLAHours sql.NullInt64
+		team := &models.Team{Members: []models.TeamMember{}}
+		if err := rows.Scan(&team.TeamName, &parentTeamName, &reviewSLAHours); err != nil {
+			return nil, err
+		}
+		if parentTeamName.Valid {
+			team.ParentTeamName = &parentTeamName.String
+		}
+		if reviewSLAHours.Valid {
+			hours := int(reviewSLAHours.Int64)
+			team.ReviewSLAHours = &hours
+		}
+		teams[team.TeamName] = team
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	memberRows, err := db.db.QueryContext(ctx, `
+		SELECT m.team_name, u.user_id, u.username, u.is_active, m.role
+		FROM team_memberships m
+		JOIN users u ON u.user_id = m.user_id
+		WHERE m.team_name = ANY($1)
+		ORDER BY u.username
+	`, pq.Array(teamNames))
+	if err != nil {
+		return nil, err
+	}
+	defer memberRows.Close()
+
+	for memberRows.Next() {
+		var teamName string
+		var member models.TeamMember
+		if err := memberRows.Scan(&teamName, &member.UserID, &member.Username, &member.IsActive, &member.Role); err != nil {
+			return nil, err
+		}
+		if team, ok := teams[teamName]; ok {
+			team.Members = append(team.Members, member)
+		}
+	}
+	return teams, memberRows.Err()
+}
+
+// ChildTeamNames returns the direct sub-teams of each parent team, by name.
+func (db *DB) ChildTeamNames(ctx context.Context, parentTeamNames []string) (map[string][]string, error) {
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT parent_team_name, team_name FROM teams
+		WHERE parent_team_name = ANY($1)
+		ORDER BY team_name
+	`, pq.Array(parentTeamNames))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	children := make(map[string][]string, len(parentTeamNames))
+	for rows.Next() {
+		var parent, child string
+		if err := rows.Scan(&parent, &child); err != nil {
+			return nil, err
+		}
+		children[parent] = append(children[parent], child)
+	}
+	return children, rows.Err()
+}
+
+// pullRequestColumns selects a pull_requests row (aliased pr) with its reviewers, see scanPullRequests.
+const pullRequestColumns = `
+	pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.priority,
+	COALESCE(pr.repository_name, ''), COALESCE(pr.parent_pull_request_id, ''), pr.created_at, pr.merged_at,
+	ARRAY(SELECT user_id FROM pr_reviewers WHERE pull_request_id = pr.pull_request_id ORDER BY id)`
+
+func scanPullRequests(rows *sql.Rows) ([]*models.PullRequest, error) {
+	defer rows.Close()
+
+	prs := []*models.PullRequest{}
+	for rows.Next() {
+		pr := &models.PullRequest{AssignedReviewers: []string{}}
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.Priority,
+			&pr.RepositoryName, &pr.ParentPullRequestID, &pr.CreatedAt, &pr.MergedAt, pq.Array(&pr.AssignedReviewers)); err != nil {
+			return nil, err
+		}
+		prs = append(prs, pr)
+	}
+	return prs, rows.Err()
+}
+
+func (db *DB) PullRequestsByID(ctx context.Context, prIDs []string) (map[string]*models.PullRequest, error) {
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT `+pullRequestColumns+` FROM pull_requests pr WHERE pr.pull_request_id = ANY($1)
+	`, pq.Array(prIDs))
+	if err != nil {
+		return nil, err
+	}
+	prs, err := scanPullRequests(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*models.PullRequest, len(prs))
+	for _, pr := range prs {
+		byID[pr.PullRequestID] = pr
+	}
+	return byID, nil
+}
+
+// PullRequestsByAuthor returns the PRs of each author, oldest first; an empty status matches any status.
+func (db *DB) PullRequestsByAuthor(ctx context.Context, authorIDs []string, status string) (map[string][]*models.PullRequest, error) {
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT `+pullRequestColumns+` FROM pull_requests pr
+		WHERE pr.author_id = ANY($1) AND ($2 = '' OR pr.status = $2)
+		ORDER BY pr.created_at, pr.pull_request_id
+	`, pq.Array(authorIDs), status)
+	if err != nil {
+		return nil, err
+	}
+	prs, err := scanPullRequests(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	byAuthor := make(map[string][]*models.PullRequest, len(authorIDs))
+	for _, pr := range prs {
+		byAuthor[pr.AuthorID] = append(byAuthor[pr.AuthorID], pr)
+	}
+	return byAuthor, nil
+}
+
+// ReviewsByReviewer returns the review assignments of each reviewer, oldest first; an empty
+// status matches PRs in any status.
+func (db *DB) ReviewsByReviewer(ctx context.Context, userIDs []string, status string) (map[string][]models.ReviewerAssignment, error) {
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT r.pull_request_id, r.user_id, r.assigned_at, r.responded_at
+		FROM pr_reviewers r
+		JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		WHERE r.user_id = ANY($1) AND ($2 = '' OR pr.status = $2)
+		ORDER BY r.assigned_at, r.id
+	`, pq.Array(userIDs), status)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := scanAssignments(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	byReviewer := make(map[string][]models.ReviewerAssignment, len(userIDs))
+	for _, a := range assignments {
+		byReviewer[a.UserID] = append(byReviewer[a.UserID], a)
+	}
+	return byReviewer, nil
+}
+
+// ReviewsByPR returns the review assignments of each PR in assignment order.
+func (db *DB) ReviewsByPR(ctx context.Context, prIDs []string) (map[string][]models.ReviewerAssignment, error) {
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT pull_request_id, user_id, assigned_at, responded_at
+		FROM pr_reviewers
+		WHERE pull_request_id = ANY($1)
+		ORDER BY id
+	`, pq.Array(prIDs))
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := scanAssignments(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	byPR := make(map[string][]models.ReviewerAssignment, len(prIDs))
+	for _, a := range assignments {
+		byPR[a.PullRequestID] = append(byPR[a.PullRequestID], a)
+	}
+	return byPR, nil
+}
+
+func scanAssignments(rows *sql.Rows) ([]models.ReviewerAssignment, error) {
+	defer rows.Close()
+
+	assignments := []models.ReviewerAssignment{}
+	for rows.Next() {
+		var a models.ReviewerAssignment
+		if err := rows.Scan(&a.PullRequestID, &a.UserID, &a.AssignedAt, &a.RespondedAt); err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, a)
+	}
+	return assignments, rows.Err()
+}
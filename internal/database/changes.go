@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/models"
+)
+
+// ChangesSince returns up to limit changes with a cursor greater than since, oldest first. The
+// changes table is filled by triggers (see log_change in migrations/init.sql). A cursor behind
+// the retention purge fails with CURSOR_EXPIRED, carrying the latest cursor to resume from
+// after a full resync.
+func (db *DB) ChangesSince(ctx context.Context, since int64, limit int) ([]models.Change, error) {
+	// One snapshot for the purge check and the page, so a purge in between cannot open a gap.
+	tx, err := db.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var purgedThrough, latest int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT purged_through, GREATEST(purged_through, (SELECT MAX(id) FROM changes)) FROM changes_purged
+	`).Scan(&purgedThrough, &latest)
+	if err != nil {
+		return nil, err
+	}
+	if since < purgedThrough {
+		message := fmt.Sprintf("changes up to cursor %d have been purged; resync from a full dump and continue from cursor %d", purgedThrough, latest)
+		return nil, apperr.New(apperr.ErrCursorExpired, message).
+			With("purged_through", strconv.FormatInt(purgedThrough, 10)).
+			With("latest_cursor", strconv.FormatInt(latest, 10))
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, entity, operation, entity_key, COALESCE(data, 'null'::jsonb), changed_at
+		FROM changes
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []models.Change{}
+	for rows.Next() {
+		var c models.Change
+		var key, data []byte
+		if err := rows.Scan(&c.Cursor, &c.Entity, &c.Operation, &key, &data, &c.ChangedAt); err != nil {
+			return nil, err
+		}
+		c.Key, c.Data = key, data
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
+
+// PurgeChanges deletes changes older than retention and records the highest purged cursor.
+func (db *DB) PurgeChanges(ctx context.Context, retention time.Duration) (int64, error) {
+	var n int64
+	err := db.db.QueryRowContext(ctx, `
+		WITH purged AS (
+			DELETE FROM changes
+			WHERE changed_at < LOCALTIMESTAMP - $1 * INTERVAL '1 second' AND id IS NOT NULL
+			RETURNING id
+		), horizon AS (
+			UPDATE changes_purged SET purged_through = GREATEST(purged_through, (SELECT MAX(id) FROM purged))
+		)
+		SELECT COUNT(*) FROM purged
+	`, int64(retention/time.Second)).Scan(&n)
+	return n, err
+}
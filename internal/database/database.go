@@ -5,9 +5,10 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
-	"math/rand/v2"
+	"strconv"
 	"time"
 
+	"pr-review-service/internal/apperr"
 	"pr-review-service/internal/models"
 
 	_ "github.com/lib/pq"
@@ -52,47 +53,66 @@ func (db *DB) CreateTeam(ctx context.Context, team *models.Team) error {
 		return err
 	}
 	if exists {
-		return fmt.Errorf(models.ErrTeamExists)
+		return apperr.ErrTeamExists.With("team_name", team.TeamName)
 	}
 
-	_, err = tx.ExecContext(ctx, "INSERT INTO teams (team_name) VALUES ($1)", team.TeamName)
+	if team.ParentTeamName != nil {
+		err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", *team.ParentTeamName).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return apperr.NotFound("parent team").With("team_name", *team.ParentTeamName)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO teams (team_name, review_sla_hours, parent_team_name) VALUES ($1, $2, $3)
+	`, team.TeamName, team.ReviewSLAHours, team.ParentTeamName)
 	if err != nil {
 		return err
 	}
 
-	for _, member := range team.Members {
-		_, err = tx.ExecContext(ctx, `
-			INSERT INTO users (user_id, username, team_name, is_active)
-			VALUES ($1, $2, $3, $4)
-			ON CONFLICT (user_id) DO UPDATE
-			SET username = EXCLUDED.username,
-			    team_name = EXCLUDED.team_name,
-			    is_active = EXCLUDED.is_active
-		`, member.UserID, member.Username, team.TeamName, member.IsActive)
-		if err != nil {
-			return err
+	for i := range team.Members {
+		if team.Members[i].Role == "" {
+			team.Members[i].Role = models.RoleMember
 		}
 	}
+	inOtherTeams, err := addMembers(ctx, tx, team.TeamName, team.Members)
+	if err != nil {
+		return err
+	}
+	if len(inOtherTeams) > 0 {
+		team.MembersInOtherTeams = inOtherTeams
+	}
 
 	return tx.Commit()
 }
 
-func (db *DB) GetTeam(ctx context.Context, teamName string) (*models.Team, error) {
-	var exists bool
-	err := db.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", teamName).Scan(&exists)
+func (db *DB) GetTeam(ctx context.Context, teamName string, includeSubteams bool) (*models.Team, error) {
+	var reviewSLAHours sql.NullInt64
+	var parentTeamName sql.NullString
+	err := db.db.QueryRowContext(ctx, `
+		SELECT review_sla_hours, parent_team_name FROM teams WHERE team_name = $1
+	`, teamName).Scan(&reviewSLAHours, &parentTeamName)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("team").With("team_name", teamName)
+	}
 	if err != nil {
 		return nil, err
 	}
-	if !exists {
-		return nil, fmt.Errorf(models.ErrNotFound)
-	}
 
-	rows, err := db.db.QueryContext(ctx, `
-		SELECT user_id, username, is_active
-		FROM users
-		WHERE team_name = $1
-		ORDER BY username
-	`, teamName)
+	rows, err := db.db.QueryContext(ctx, teamScope("$1", "$2")+`
+		SELECT u.user_id, u.username, u.is_active, m.role
+		FROM users u
+		JOIN LATERAL (
+			SELECT role FROM team_memberships
+			WHERE user_id = u.user_id AND team_name IN (SELECT team_name FROM scope)
+			ORDER BY team_name = $1 DESC, team_name
+			LIMIT 1
+		) m ON true
+		ORDER BY u.username
+	`, teamName, includeSubteams)
 	if err != nil {
 		return nil, err
 	}
@@ -101,35 +121,77 @@ func (db *DB) GetTeam(ctx context.Context, teamName string) (*models.Team, error
 	members := []models.TeamMember{}
 	for rows.Next() {
 		var member models.TeamMember
-		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive); err != nil {
+		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive, &member.Role); err != nil {
 			return nil, err
 		}
 		members = append(members, member)
 	}
 
-	return &models.Team{
+	team := &models.Team{
 		TeamName: teamName,
 		Members:  members,
-	}, nil
+	}
+	if reviewSLAHours.Valid {
+		hours := int(reviewSLAHours.Int64)
+		team.ReviewSLAHours = &hours
+	}
+	if parentTeamName.Valid {
+		team.ParentTeamName = &parentTeamName.String
+	}
+	return team, nil
 }
 
 func (db *DB) SetUserActive(ctx context.Context, userID string, isActive bool) (*models.User, error) {
-	var user models.User
-	err := db.db.QueryRowContext(ctx, `
-		UPDATE users
-		SET is_active = $2
-		WHERE user_id = $1
-		RETURNING user_id, username, team_name, is_active
-	`, userID, isActive).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := setUserActive(ctx, tx, userID, isActive); err != nil {
+		return nil, err
+	}
 
+	user, err := loadUser(ctx, tx, userID)
 	if err != nil {
-		return nil, fmt.Errorf(models.ErrNotFound)
+		return nil, err
+	}
+	return user, tx.Commit()
+}
+
+func setUserActive(ctx context.Context, tx *sql.Tx, userID string, isActive bool) error {
+	var wasActive bool
+	err := tx.QueryRowContext(ctx, `
+		SELECT is_active FROM users WHERE user_id = $1 AND deleted_at IS NULL FOR UPDATE
+	`, userID).Scan(&wasActive)
+	if err == sql.ErrNoRows {
+		return missingUser(ctx, tx, userID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if wasActive == isActive {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE users SET is_active = $2 WHERE user_id = $1", userID, isActive); err != nil {
+		return err
 	}
+	return recordActivation(ctx, tx, userID, isActive)
+}
 
-	return &user, nil
+func (db *DB) CreatePR(ctx context.Context, req *models.PullRequest, inheritReviewers bool) (*models.PullRequest, error) {
+	return db.CreateBatchPR(ctx, req, inheritReviewers, nil)
 }
 
-func (db *DB) CreatePR(ctx context.Context, prID, prName, authorID string) (*models.PullRequest, error) {
+// BatchLoad counts the reviews assigned to each user by the PRs created so far in one batch.
+type BatchLoad map[string]int
+
+// CreateBatchPR creates one PR of a bulk registration in its own transaction. Reviewer
+// selection prefers users that got fewer reviews earlier in the batch, so hundreds of PRs
+// registered at once are spread over the team instead of piling onto whoever comes up first;
+// load is updated with the reviewers assigned. A nil load behaves like CreatePR.
+func (db *DB) CreateBatchPR(ctx context.Context, req *models.PullRequest, inheritReviewers bool, load BatchLoad) (*models.PullRequest, error) {
 	tx, err := db.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
@@ -137,73 +199,122 @@ func (db *DB) CreatePR(ctx context.Context, prID, prName, authorID string) (*mod
 	defer tx.Rollback()
 
 	var exists bool
-	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = $1)", prID).Scan(&exists)
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = $1)", req.PullRequestID).Scan(&exists)
 	if err != nil {
 		return nil, err
 	}
 	if exists {
-		return nil, fmt.Errorf(models.ErrPRExists)
+		return nil, apperr.ErrPRExists.With("pull_request_id", req.PullRequestID)
 	}
 
 	var teamName string
-	err = tx.QueryRowContext(ctx, "SELECT team_name FROM users WHERE user_id = $1", authorID).Scan(&teamName)
+	err = tx.QueryRowContext(ctx, "SELECT "+primaryTeam+" FROM users WHERE user_id = $1 AND deleted_at IS NULL", req.AuthorID).Scan(&teamName)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("author").With("user_id", req.AuthorID)
+	}
 	if err != nil {
-		return nil, fmt.Errorf(models.ErrNotFound)
+		return nil, err
+	}
+
+	reviewerCount, strategy := models.DefaultReviewerCount, models.StrategyRandom
+	var repositoryName *string
+	if req.RepositoryName != "" {
+		repositoryName = &req.RepositoryName
+		err = tx.QueryRowContext(ctx, `
+			SELECT team_name, reviewer_count, strategy FROM repositories WHERE repository_name = $1
+		`, req.RepositoryName).Scan(&teamName, &reviewerCount, &strategy)
+		if err == sql.ErrNoRows {
+			return nil, apperr.NotFound("repository").With("repository_name", req.RepositoryName)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var parentID *string
+	if req.ParentPullRequestID != "" {
+		parentID = &req.ParentPullRequestID
+		err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = $1)", req.ParentPullRequestID).Scan(&exists)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, apperr.NotFound("parent PR").With("pull_request_id", req.ParentPullRequestID)
+		}
 	}
 
 	now := time.Now()
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at)
-		VALUES ($1, $2, $3, $4, $5)
-	`, prID, prName, authorID, models.StatusOpen, now)
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, priority, repository_name, parent_pull_request_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, req.PullRequestID, req.PullRequestName, req.AuthorID, models.StatusOpen, req.Priority, repositoryName, parentID, now)
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := tx.QueryContext(ctx, `
-		SELECT user_id FROM users
-		WHERE team_name = $1 AND is_active = true AND user_id != $2
-	`, teamName, authorID)
-	if err != nil {
-		return nil, err
+	reviewers := []string{}
+	if parentID != nil && inheritReviewers {
+		reviewers, err = loadInheritedReviewers(ctx, tx, *parentID, req.AuthorID)
+		if err != nil {
+			return nil, err
+		}
 	}
-	defer rows.Close()
-
-	candidates := []string{}
-	for rows.Next() {
-		var userID string
-		if err := rows.Scan(&userID); err != nil {
+	if len(reviewers) == 0 {
+		levels, err := loadCandidateLevels(ctx, tx, teamName, req.AuthorID, req.Priority, reviewerCount, nil)
+		if err != nil {
 			return nil, err
 		}
-		candidates = append(candidates, userID)
+		for _, level := range levels {
+			for i := range level {
+				level[i].batchLoad = load[level[i].userID]
+			}
+		}
+		reviewers = selectFromLevels(levels, reviewerCount, strategy)
 	}
-
-	reviewers := selectRandomReviewers(candidates, 2)
 	for _, reviewerID := range reviewers {
 		_, err = tx.ExecContext(ctx, `
 			INSERT INTO pr_reviewers (pull_request_id, user_id)
 			VALUES ($1, $2)
-		`, prID, reviewerID)
+		`, req.PullRequestID, reviewerID)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	pr := &models.PullRequest{
+		PullRequestID:       req.PullRequestID,
+		PullRequestName:     req.PullRequestName,
+		AuthorID:            req.AuthorID,
+		Status:              models.StatusOpen,
+		Priority:            req.Priority,
+		RepositoryName:      req.RepositoryName,
+		ParentPullRequestID: req.ParentPullRequestID,
+		AssignedReviewers:   reviewers,
+		CreatedAt:           &now,
+		Version:             1,
+	}
+	if err := recordEvent(ctx, tx, models.EventPRCreated, append([]string{pr.AuthorID}, reviewers...), pr); err != nil {
+		return nil, err
+	}
+	if err := recordAssignment(ctx, tx, pr.PullRequestID, pr.AuthorID, reviewers); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
+	if load != nil {
+		for _, reviewerID := range reviewers {
+			load[reviewerID]++
+		}
+	}
 
-	return &models.PullRequest{
-		PullRequestID:     prID,
-		PullRequestName:   prName,
-		AuthorID:          authorID,
-		Status:            models.StatusOpen,
-		AssignedReviewers: reviewers,
-		CreatedAt:         &now,
-	}, nil
+	return pr, nil
 }
 
-func (db *DB) MergePR(ctx context.Context, prID string) (*models.PullRequest, error) {
+// MergePR merges an OPEN PR; merging a MERGED PR again returns it unchanged. expectedVersion is
+// the version the client last read (0 for any); the PR row stays locked until the merge commits.
+func (db *DB) MergePR(ctx context.Context, prID string, expectedVersion int) (*models.PullRequest, error) {
 	tx, err := db.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
@@ -213,66 +324,122 @@ func (db *DB) MergePR(ctx context.Context, prID string) (*models.PullRequest, er
 	var pr models.PullRequest
 	var mergedAt *time.Time
 	err = tx.QueryRowContext(ctx, `
-		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+		SELECT pull_request_id, pull_request_name, author_id, status, priority,
+		       COALESCE(repository_name, ''), COALESCE(parent_pull_request_id, ''), created_at, merged_at, version
 		FROM pull_requests
 		WHERE pull_request_id = $1
-	`, prID).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt)
-
+		FOR UPDATE
+	`, prID).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.Priority,
+		&pr.RepositoryName, &pr.ParentPullRequestID, &pr.CreatedAt, &mergedAt, &pr.Version)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("PR").With("pull_request_id", prID)
+	}
 	if err != nil {
-		return nil, fmt.Errorf(models.ErrNotFound)
+		return nil, err
+	}
+	if err := checkVersion(prID, pr.Version, expectedVersion); err != nil {
+		return nil, err
 	}
 
 	if pr.Status == models.StatusMerged {
 		pr.MergedAt = mergedAt
-		rows, _ := tx.QueryContext(ctx, `SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1`, prID)
-		reviewers := []string{}
-		for rows.Next() {
-			var userID string
-			if rows.Scan(&userID) == nil {
-				reviewers = append(reviewers, userID)
-			}
+		if pr.AssignedReviewers, err = loadReviewers(ctx, tx, prID); err != nil {
+			return nil, err
 		}
-		rows.Close()
-		pr.AssignedReviewers = reviewers
 		return &pr, nil
 	}
 
+	if pr.ParentPullRequestID != "" {
+		var parentStatus string
+		err = tx.QueryRowContext(ctx, "SELECT status FROM pull_requests WHERE pull_request_id = $1", pr.ParentPullRequestID).Scan(&parentStatus)
+		if err != nil {
+			return nil, err
+		}
+		if parentStatus != models.StatusMerged {
+			return nil, apperr.ErrParentNotMerged.With("pull_request_id", prID).With("parent_pull_request_id", pr.ParentPullRequestID)
+		}
+	}
+
 	now := time.Now()
-	_, err = tx.ExecContext(ctx, `
+	err = tx.QueryRowContext(ctx, `
 		UPDATE pull_requests
-		SET status = $2, merged_at = $3
+		SET status = $2, merged_at = $3, version = version + 1
 		WHERE pull_request_id = $1
-	`, prID, models.StatusMerged, now)
+		RETURNING version
+	`, prID, models.StatusMerged, now).Scan(&pr.Version)
 	if err != nil {
 		return nil, err
 	}
 
+	if pr.AssignedReviewers, err = loadReviewers(ctx, tx, prID); err != nil {
+		return nil, err
+	}
+	err = recordEvent(ctx, tx, models.EventPRMerged, append([]string{pr.AuthorID}, pr.AssignedReviewers...), map[string]interface{}{
+		"pull_request_id": prID,
+		"author_id":       pr.AuthorID,
+		"reviewers":       pr.AssignedReviewers,
+		"merged_at":       now,
+	})
+	if err != nil {
+		return nil, err
+	}
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
 
 	pr.Status = models.StatusMerged
 	pr.MergedAt = &now
-	pr.AssignedReviewers = db.getReviewersFromDB(ctx, prID)
 
 	return &pr, nil
 }
 
-func (db *DB) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*models.PullRequest, string, error) {
+// ReassignReviewer replaces oldUserID on an OPEN PR; expectedVersion is the version the client
+// last read, or 0 for any.
+func (db *DB) ReassignReviewer(ctx context.Context, prID, oldUserID string, expectedVersion int) (*models.PullRequest, string, error) {
 	tx, err := db.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, "", err
 	}
 	defer tx.Rollback()
 
-	var status string
-	err = tx.QueryRowContext(ctx, "SELECT status FROM pull_requests WHERE pull_request_id = $1", prID).Scan(&status)
+	newReviewer, err := reassignReviewer(ctx, tx, prID, oldUserID, expectedVersion)
 	if err != nil {
-		return nil, "", fmt.Errorf(models.ErrNotFound)
+		return nil, "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, "", err
+	}
+
+	pr, err := db.GetPR(ctx, prID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return pr, newReviewer, nil
+}
+
+// reassignReviewer replaces oldUserID on an OPEN PR with a candidate from the author's or repository's team.
+// The PR row is locked first, so concurrent reassignments of the same PR run one after another.
+func reassignReviewer(ctx context.Context, tx *sql.Tx, prID, oldUserID string, expectedVersion int) (string, error) {
+	var status, priority string
+	var repositoryName sql.NullString
+	var version int
+	err := tx.QueryRowContext(ctx, `
+		SELECT status, priority, repository_name, version FROM pull_requests WHERE pull_request_id = $1 FOR UPDATE
+	`, prID).Scan(&status, &priority, &repositoryName, &version)
+	if err == sql.ErrNoRows {
+		return "", apperr.NotFound("PR").With("pull_request_id", prID)
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := checkVersion(prID, version, expectedVersion); err != nil {
+		return "", err
 	}
 
 	if status == models.StatusMerged {
-		return nil, "", fmt.Errorf(models.ErrPRMerged)
+		return "", apperr.ErrPRMerged.With("pull_request_id", prID)
 	}
 
 	var isAssigned bool
@@ -280,70 +447,55 @@ func (db *DB) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*mo
 		SELECT EXISTS(SELECT 1 FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2)
 	`, prID, oldUserID).Scan(&isAssigned)
 	if err != nil {
-		return nil, "", err
+		return "", err
 	}
 	if !isAssigned {
-		return nil, "", fmt.Errorf(models.ErrNotAssigned)
+		return "", apperr.ErrNotAssigned.With("pull_request_id", prID).With("user_id", oldUserID)
 	}
 
 	var teamName, authorID string
 	err = tx.QueryRowContext(ctx, `
-		SELECT u.team_name, pr.author_id
-		FROM users u, pull_requests pr
-		WHERE u.user_id = $1 AND pr.pull_request_id = $2
-	`, oldUserID, prID).Scan(&teamName, &authorID)
+		SELECT COALESCE(m.team_name, ''), pr.author_id
+		FROM pull_requests pr
+		LEFT JOIN team_memberships m ON m.user_id = pr.author_id AND m.is_primary
+		WHERE pr.pull_request_id = $1
+	`, prID).Scan(&teamName, &authorID)
 	if err != nil {
-		return nil, "", err
+		return "", err
 	}
 
-	rowsCurr, _ := tx.QueryContext(ctx, `SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1`, prID)
-	currentReviewers := []string{}
-	for rowsCurr.Next() {
-		var userID string
-		if rowsCurr.Scan(&userID) == nil {
-			currentReviewers = append(currentReviewers, userID)
+	strategy := models.StrategyRandom
+	if repositoryName.Valid {
+		err = tx.QueryRowContext(ctx, `
+			SELECT team_name, strategy FROM repositories WHERE repository_name = $1
+		`, repositoryName.String).Scan(&teamName, &strategy)
+		if err != nil {
+			return "", err
 		}
 	}
-	rowsCurr.Close()
 
-	rows, err := tx.QueryContext(ctx, `
-		SELECT user_id FROM users
-		WHERE team_name = $1 AND is_active = true AND user_id != $2
-	`, teamName, authorID)
+	currentReviewers, err := loadReviewers(ctx, tx, prID)
 	if err != nil {
-		return nil, "", err
+		return "", err
 	}
-	defer rows.Close()
 
-	candidates := []string{}
-	for rows.Next() {
-		var userID string
-		if err := rows.Scan(&userID); err != nil {
-			return nil, "", err
-		}
-		isCurrentReviewer := false
-		for _, r := range currentReviewers {
-			if r == userID {
-				isCurrentReviewer = true
-				break
-			}
-		}
-		if !isCurrentReviewer {
-			candidates = append(candidates, userID)
-		}
+	levels, err := loadCandidateLevels(ctx, tx, teamName, authorID, priority, 1, currentReviewers)
+	if err != nil {
+		return "", err
 	}
 
-	if len(candidates) == 0 {
-		return nil, "", fmt.Errorf(models.ErrNoCandidate)
+	selected := selectFromLevels(levels, 1, strategy)
+	if len(selected) == 0 {
+		return "", apperr.ErrNoCandidate.With("pull_request_id", prID)
 	}
 
-	newReviewer := candidates[rand.IntN(len(candidates))]
+	newReviewer := selected[0]
 
 	_, err = tx.ExecContext(ctx, `
 		DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2
 	`, prID, oldUserID)
 	if err != nil {
-		return nil, "", err
+		return "", err
 	}
 
 	_, err = tx.ExecContext(ctx, `
@@ -351,38 +503,53 @@ func (db *DB) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*mo
 		VALUES ($1, $2)
 	`, prID, newReviewer)
 	if err != nil {
-		return nil, "", err
+		return "", err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, "", err
+	if err := bumpVersion(ctx, tx, prID); err != nil {
+		return "", err
 	}
-
-	pr, err := db.GetPR(ctx, prID)
+	err = recordEvent(ctx, tx, models.EventReviewerReassigned, []string{authorID, oldUserID, newReviewer}, map[string]interface{}{
+		"pull_request_id": prID,
+		"author_id":       authorID,
+		"old_user_id":     oldUserID,
+		"new_user_id":     newReviewer,
+	})
 	if err != nil {
-		return nil, "", err
+		return "", err
 	}
-
-	return pr, newReviewer, nil
+	if err := recordAssignment(ctx, tx, prID, authorID, []string{newReviewer}); err != nil {
+		return "", err
+	}
+	return newReviewer, nil
 }
 
-func (db *DB) GetUserReviews(ctx context.Context, userID string) ([]models.PullRequestShort, error) {
+// urgencyScore ranks reviews by priority, plus one point per hour the PR has been open.
+const urgencyScore = `
+	(CASE pr.priority WHEN 'hotfix' THEN 1000 WHEN 'high' THEN 48 WHEN 'normal' THEN 24 ELSE 0 END
+	 + EXTRACT(EPOCH FROM (LOCALTIMESTAMP - pr.created_at)) / 3600)::DOUBLE PRECISION`
+
+func (db *DB) GetUserReviews(ctx context.Context, userID, status string) ([]models.UserReview, error) {
 	rows, err := db.db.QueryContext(ctx, `
-		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.priority,
+		       `+urgencyScore+`,
+		       r.assigned_at, r.responded_at,
+		       EXTRACT(EPOCH FROM (COALESCE(r.responded_at, LOCALTIMESTAMP) - r.assigned_at))::BIGINT
 		FROM pull_requests pr
 		JOIN pr_reviewers r ON pr.pull_request_id = r.pull_request_id
-		WHERE r.user_id = $1
-		ORDER BY pr.created_at DESC
-	`, userID)
+		WHERE r.user_id = $1 AND ($2 = '' OR pr.status = $2)
+		ORDER BY 6 DESC, pr.created_at
+	`, userID, status)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	prs := []models.PullRequestShort{}
+	prs := []models.UserReview{}
 	for rows.Next() {
-		var pr models.PullRequestShort
-		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status); err != nil {
+		var pr models.UserReview
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.Priority,
+			&pr.UrgencyScore, &pr.AssignedAt, &pr.RespondedAt, &pr.WaitingSeconds); err != nil {
 			return nil, err
 		}
 		prs = append(prs, pr)
@@ -394,47 +561,56 @@ func (db *DB) GetUserReviews(ctx context.Context, userID string) ([]models.PullR
 func (db *DB) GetPR(ctx context.Context, prID string) (*models.PullRequest, error) {
 	var pr models.PullRequest
 	err := db.db.QueryRowContext(ctx, `
-		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+		SELECT pull_request_id, pull_request_name, author_id, status, priority,
+		       COALESCE(repository_name, ''), COALESCE(parent_pull_request_id, ''), created_at, merged_at, version
 		FROM pull_requests
 		WHERE pull_request_id = $1
-	`, prID).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt)
-
+	`, prID).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.Priority,
+		&pr.RepositoryName, &pr.ParentPullRequestID, &pr.CreatedAt, &pr.MergedAt, &pr.Version)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("PR").With("pull_request_id", prID)
+	}
 	if err != nil {
-		return nil, fmt.Errorf(models.ErrNotFound)
+		return nil, err
 	}
 
-	pr.AssignedReviewers = db.getReviewersFromDB(ctx, prID)
+	if pr.AssignedReviewers, err = loadReviewers(ctx, db.db, prID); err != nil {
+		return nil, err
+	}
 	return &pr, nil
 }
 
-func (db *DB) getReviewersFromDB(ctx context.Context, prID string) []string {
-	rows, err := db.db.QueryContext(ctx, `
+// checkVersion rejects a change to a PR that was modified since the client read version
+// expectedVersion; 0 means the client sent no precondition.
+func checkVersion(prID string, version, expectedVersion int) error {
+	if expectedVersion != 0 && version != expectedVersion {
+		return apperr.ErrVersionMismatch.With("pull_request_id", prID).With("version", strconv.Itoa(version))
+	}
+	return nil
+}
+
+// bumpVersion marks a change of the PR's reviewers.
+func bumpVersion(ctx context.Context, tx *sql.Tx, prID string) error {
+	_, err := tx.ExecContext(ctx, "UPDATE pull_requests SET version = version + 1 WHERE pull_request_id = $1", prID)
+	return err
+}
+
+func loadReviewers(ctx context.Context, q querier, prID string) ([]string, error) {
+	rows, err := q.QueryContext(ctx, `
 		SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1
 	`, prID)
 	if err != nil {
-		return []string{}
+		return nil, err
 	}
 	defer rows.Close()
 
 	reviewers := []string{}
 	for rows.Next() {
 		var userID string
-		if err := rows.Scan(&userID); err == nil {
-			reviewers = append(reviewers, userID)
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
 		}
+		reviewers = append(reviewers, userID)
 	}
-	return reviewers
-}
-
-func selectRandomReviewers(candidates []string, max int) []string {
-	if len(candidates) <= max {
-		return candidates
-	}
-
-	selected := make([]string, max)
-	perm := rand.Perm(len(candidates))
-	for i := 0; i < max; i++ {
-		selected[i] = candidates[perm[i]]
-	}
-	return selected
+	return reviewers, rows.Err()
 }
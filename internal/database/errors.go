@@ -0,0 +1,34 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	"github.com/lib/pq"
+)
+
+// IsUnavailable reports whether err means Postgres could not be reached or refused to do the
+// work right now, as opposed to a rejected query or a bug. Callers answer such errors with 503.
+func IsUnavailable(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code.Class() {
+		// connection_exception, insufficient_resources, operator_intervention (shutdown, statement timeout)
+		case "08", "53", "57":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/lib/pq"
+
+	"pr-review-service/internal/models"
+)
+
+// recordEvent appends an event to the log within tx; it gets its id when tx commits (see
+// assign_log_id in migrations/init.sql). The teams of userIDs are captured now, so a team filter
+// still matches after the users move on.
+func recordEvent(ctx context.Context, tx *sql.Tx, eventType string, userIDs []string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	userIDs = slices.Compact(slices.Sorted(slices.Values(userIDs)))
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (event_type, user_ids, team_names, payload)
+		VALUES ($1, $2, ARRAY(
+			SELECT DISTINCT team_name FROM team_memberships WHERE user_id = ANY($2) ORDER BY team_name
+		), $3)
+	`, eventType, pq.Array(userIDs), payload)
+	return err
+}
+
+// recordAssignment records reviewers being assigned to a PR, on creation or reassignment.
+func recordAssignment(ctx context.Context, tx *sql.Tx, prID, authorID string, reviewers []string) error {
+	if len(reviewers) == 0 {
+		return nil
+	}
+	return recordEvent(ctx, tx, models.EventReviewersAssigned, append([]string{authorID}, reviewers...), map[string]interface{}{
+		"pull_request_id": prID,
+		"author_id":       authorID,
+		"reviewers":       reviewers,
+	})
+}
+
+func recordActivation(ctx context.Context, tx *sql.Tx, userID string, isActive bool) error {
+	return recordEvent(ctx, tx, models.EventUserActivation, []string{userID}, map[string]interface{}{
+		"user_id":   userID,
+		"is_active": isActive,
+	})
+}
+
+// LatestEventID returns the ID of the newest event, or 0 when there are none.
+func (db *DB) LatestEventID(ctx context.Context) (int64, error) {
+	var id int64
+	err := db.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(id), 0) FROM events").Scan(&id)
+	return id, err
+}
+
+// EventsAfter returns up to limit events newer than afterID that match filter, oldest first.
+func (db *DB) EventsAfter(ctx context.Context, afterID int64, filter models.EventFilter, limit int) ([]*models.Event, error) {
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT id, event_type, user_ids, team_names, payload, created_at
+		FROM events
+		WHERE id > $1 AND ($2 = '' OR $2 = ANY(team_names)) AND ($3 = '' OR $3 = ANY(user_ids))
+		ORDER BY id
+		LIMIT $4
+	`, afterID, filter.TeamName, filter.UserID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*models.Event{}
+	for rows.Next() {
+		var e models.Event
+		var payload []byte
+		if err := rows.Scan(&e.ID, &e.Type, pq.Array(&e.UserIDs), pq.Array(&e.TeamNames), &payload, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Data = payload
+		events = append(events, &e)
+	}
+	return events, rows.Err()
+}
+
+// PurgeEvents deletes events older than retention; clients resuming from them get the rest.
+func (db *DB) PurgeEvents(ctx context.Context, retention time.Duration) (int64, error) {
+	res, err := db.db.ExecContext(ctx, `
+		DELETE FROM events WHERE created_at < LOCALTIMESTAMP - $1 * INTERVAL '1 second'
+	`, int64(retention/time.Second))
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
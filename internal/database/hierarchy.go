@@ -0,0 +1,253 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/models"
+)
+
+// teamAncestors returns the parent chain of a team, nearest first. The path column stops the
+// recursion once a team repeats, so a cyclic hierarchy ends the chain (with the team itself
+// among its ancestors) instead of looping.
+func teamAncestors(ctx context.Context, q querier, teamName string) ([]string, error) {
+	rows, err := q.QueryContext(ctx, `
+		WITH RECURSIVE ancestors AS (
+			SELECT parent_team_name AS team_name, 1 AS depth, ARRAY[team_name::TEXT] AS path
+			FROM teams WHERE team_name = $1 AND parent_team_name IS NOT NULL
+			UNION ALL
+			SELECT t.parent_team_name, a.depth + 1, a.path || t.team_name::TEXT
+			FROM teams t JOIN ancestors a ON t.team_name = a.team_name
+			WHERE t.parent_team_name IS NOT NULL AND t.team_name::TEXT <> ALL(a.path)
+		)
+		SELECT team_name FROM ancestors ORDER BY depth
+	`, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ancestors := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		ancestors = append(ancestors, name)
+	}
+	return ancestors, rows.Err()
+}
+
+// lockHierarchy serialises changes of team parents until the transaction ends. Without it two
+// concurrent moves (a under b, b under a) could each pass the cycle check against a tree the
+// other has not changed yet and together commit a cycle.
+func lockHierarchy(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('pr-review-service/hierarchy'))")
+	return err
+}
+
+// parentCycle returns the chain teamName, parentTeamName, ..., teamName that nesting teamName
+// under parentTeamName would close, given the parent's ancestors nearest first, or nil.
+func parentCycle(teamName, parentTeamName string, parentAncestors []string) []string {
+	chain := []string{teamName}
+	for _, name := range append([]string{parentTeamName}, parentAncestors...) {
+		chain = append(chain, name)
+		if name == teamName {
+			return chain
+		}
+	}
+	return nil
+}
+
+func (db *DB) SetTeamParent(ctx context.Context, teamName string, parentTeamName *string) (*models.Team, error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := setTeamParent(ctx, tx, teamName, parentTeamName); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return db.GetTeam(ctx, teamName, false)
+}
+
+func setTeamParent(ctx context.Context, tx *sql.Tx, teamName string, parentTeamName *string) error {
+	var exists bool
+	err := tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", teamName).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apperr.NotFound("team").With("team_name", teamName)
+	}
+
+	if parentTeamName != nil {
+		err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", *parentTeamName).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return apperr.NotFound("parent team").With("team_name", *parentTeamName)
+		}
+
+		if err := lockHierarchy(ctx, tx); err != nil {
+			return err
+		}
+		ancestors, err := teamAncestors(ctx, tx, *parentTeamName)
+		if err != nil {
+			return err
+		}
+		if cycle := parentCycle(teamName, *parentTeamName, ancestors); cycle != nil {
+			return apperr.New(apperr.ErrTeamCycle, "teams would form a cycle: "+strings.Join(cycle, " -> ")).With("team_name", teamName)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE teams SET parent_team_name = $2 WHERE team_name = $1", teamName, parentTeamName)
+	return err
+}
+
+// GetTeamTree returns the org tree rooted at teamName, or the whole forest when teamName is empty.
+func (db *DB) GetTeamTree(ctx context.Context, teamName string) ([]*models.TeamNode, error) {
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT t.team_name, t.parent_team_name,
+		       (SELECT COUNT(*) FROM team_memberships m WHERE m.team_name = t.team_name)
+		FROM teams t
+		ORDER BY t.team_name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	order := []*models.TeamNode{}
+	for rows.Next() {
+		var node models.TeamNode
+		var parent sql.NullString
+		if err := rows.Scan(&node.TeamName, &parent, &node.MemberCount); err != nil {
+			return nil, err
+		}
+		if parent.Valid {
+			node.ParentTeamName = &parent.String
+		}
+		order = append(order, &node)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tree, ok := buildTeamTree(order, teamName)
+	if !ok {
+		return nil, apperr.NotFound("team").With("team_name", teamName)
+	}
+	return tree, nil
+}
+
+// buildTeamTree links the teams to their parents, keeping their order among siblings, and returns
+// the subtree rooted at teamName, or all roots when teamName is empty. Teams whose parent is not
+// listed become roots. ok is false when teamName is not listed.
+func buildTeamTree(order []*models.TeamNode, teamName string) ([]*models.TeamNode, bool) {
+	nodes := make(map[string]*models.TeamNode, len(order))
+	for _, node := range order {
+		node.Children = []*models.TeamNode{}
+		nodes[node.TeamName] = node
+	}
+
+	roots := []*models.TeamNode{}
+	for _, node := range order {
+		if node.ParentTeamName != nil {
+			if parent, ok := nodes[*node.ParentTeamName]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	if teamName == "" {
+		return roots, true
+	}
+	node, ok := nodes[teamName]
+	if !ok {
+		return nil, false
+	}
+	return []*models.TeamNode{node}, true
+}
+
+// ListTeamNames pages through all team names alphabetically.
+func (db *DB) ListTeamNames(ctx context.Context, limit, offset int) ([]string, int, error) {
+	var total int
+	if err := db.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM teams").Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT team_name FROM teams ORDER BY team_name LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	teamNames := []string{}
+	for rows.Next() {
+		var teamName string
+		if err := rows.Scan(&teamName); err != nil {
+			return nil, 0, err
+		}
+		teamNames = append(teamNames, teamName)
+	}
+	return teamNames, total, rows.Err()
+}
+
+func (db *DB) GetTeamStats(ctx context.Context, teamName string, includeSubteams bool) (*models.TeamStats, error) {
+	var exists bool
+	err := db.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", teamName).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apperr.NotFound("team").With("team_name", teamName)
+	}
+
+	stats := models.TeamStats{TeamName: teamName, IncludeSubteams: includeSubteams}
+	err = db.db.QueryRowContext(ctx, teamScope("$1", "$2")+`, members AS (
+			SELECT DISTINCT u.user_id, u.is_active
+			FROM users u
+			JOIN team_memberships m ON m.user_id = u.user_id
+			WHERE m.team_name IN (SELECT team_name FROM scope)
+		)
+		SELECT
+			(SELECT COUNT(*) FROM scope),
+			(SELECT COUNT(*) FROM members),
+			(SELECT COUNT(*) FROM members WHERE is_active),
+			(SELECT COUNT(*) FROM pull_requests pr
+			 WHERE pr.status = 'OPEN' AND pr.author_id IN (SELECT user_id FROM members)),
+			(SELECT COUNT(*) FROM pr_reviewers r
+			 JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+			 WHERE pr.status = 'OPEN' AND r.responded_at IS NULL AND r.user_id IN (SELECT user_id FROM members))
+	`, teamName, includeSubteams).Scan(&stats.TeamCount, &stats.MemberCount, &stats.ActiveMemberCount,
+		&stats.OpenPullRequests, &stats.PendingReviews)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// teamScope builds a CTE "scope" listing the team and, when the flag parameter is true, all of its sub-teams.
+func teamScope(teamParam, includeSubteamsParam string) string {
+	return `
+	WITH RECURSIVE scope AS (
+		SELECT team_name FROM teams WHERE team_name = ` + teamParam + `
+		UNION
+		SELECT t.team_name FROM teams t JOIN scope s ON t.parent_team_name = s.team_name AND ` + includeSubteamsParam + `::BOOLEAN
+	)`
+}
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"strings"
+	"testing"
+
+	"pr-review-service/internal/models"
+)
+
+func TestParentCycle(t *testing.T) {
+	tests := []struct {
+		name      string
+		team      string
+		parent    string
+		ancestors []string
+		cycle     string
+	}{
+		{
+			name:   "self parent",
+			team:   "a",
+			parent: "a",
+			cycle:  "a -> a",
+		},
+		{
+			name:      "under direct child",
+			team:      "a",
+			parent:    "b",
+			ancestors: []string{"a"},
+			cycle:     "a -> b -> a",
+		},
+		{
+			name:      "under deeper descendant",
+			team:      "a",
+			parent:    "c",
+			ancestors: []string{"b", "a", "root"},
+			cycle:     "a -> c -> b -> a",
+		},
+		{
+			name:      "under unrelated team",
+			team:      "a",
+			parent:    "x",
+			ancestors: []string{"y", "root"},
+		},
+		{
+			name:      "under own ancestor",
+			team:      "a",
+			parent:    "root",
+			ancestors: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cycle := strings.Join(parentCycle(tt.team, tt.parent, tt.ancestors), " -> ")
+			if cycle != tt.cycle {
+				t.Fatalf("want cycle %q, got %q", tt.cycle, cycle)
+			}
+		})
+	}
+}
+
+func teamNodes(parents ...string) []*models.TeamNode {
+	nodes := []*models.TeamNode{}
+	for i := 0; i < len(parents); i += 2 {
+		node := &models.TeamNode{TeamName: parents[i]}
+		if parents[i+1] != "" {
+			node.ParentTeamName = &parents[i+1]
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// treeString renders nodes as "name(child child(...))" for comparison.
+func treeString(nodes []*models.TeamNode) string {
+	parts := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		part := node.TeamName
+		if len(node.Children) > 0 {
+			part += "(" + treeString(node.Children) + ")"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestBuildTeamTree(t *testing.T) {
+	tests := []struct {
+		name     string
+		teamName string
+		tree     string
+	}{
+		{name: "whole forest", tree: "eng(backend(payments) frontend) orphan sales"},
+		{name: "subtree", teamName: "backend", tree: "backend(payments)"},
+		{name: "leaf", teamName: "payments", tree: "payments"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := teamNodes(
+				"backend", "eng",
+				"eng", "",
+				"frontend", "eng",
+				"orphan", "gone",
+				"payments", "backend",
+				"sales", "",
+			)
+			tree, ok := buildTeamTree(nodes, tt.teamName)
+			if !ok {
+				t.Fatalf("team %q not found", tt.teamName)
+			}
+			if got := treeString(tree); got != tt.tree {
+				t.Fatalf("want tree %q, got %q", tt.tree, got)
+			}
+		})
+	}
+}
+
+func TestBuildTeamTreeUnknownTeam(t *testing.T) {
+	if _, ok := buildTeamTree(teamNodes("eng", ""), "missing"); ok {
+		t.Fatal("want unknown team to be reported")
+	}
+}
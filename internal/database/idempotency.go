@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"pr-review-service/internal/models"
+)
+
+// idempotencyLockTimeout is how long a request may hold a key without completing it. After that
+// a retry with the same body takes the key over, e.g. when the replica serving it crashed.
+const idempotencyLockTimeout = time.Minute
+
+// ClaimIdempotencyKey reserves key for a request whose method, path and body hash to requestHash.
+// When the key is new, expired or abandoned it returns claimed = true and the caller must
+// CompleteIdempotencyKey or ReleaseIdempotencyKey it; otherwise it returns what is stored.
+func (db *DB) ClaimIdempotencyKey(ctx context.Context, key, requestHash string, ttl time.Duration) (stored *models.IdempotentResponse, claimed bool, err error) {
+	err = db.db.QueryRowContext(ctx, `
+		INSERT INTO idempotency_keys (idempotency_key, request_hash, expires_at)
+		VALUES ($1, $2, LOCALTIMESTAMP + $3 * INTERVAL '1 second')
+		ON CONFLICT (idempotency_key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, response_status = NULL, response_headers = NULL,
+		    response_body = NULL, created_at = LOCALTIMESTAMP, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= LOCALTIMESTAMP
+		   OR (idempotency_keys.response_status IS NULL
+		       AND idempotency_keys.request_hash = EXCLUDED.request_hash
+		       AND idempotency_keys.created_at <= LOCALTIMESTAMP - $4 * INTERVAL '1 second')
+		RETURNING true
+	`, key, requestHash, int64(ttl/time.Second), int64(idempotencyLockTimeout/time.Second)).Scan(&claimed)
+	if err == nil {
+		return nil, true, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, false, err
+	}
+
+	var status sql.NullInt64
+	var header []byte
+	stored = &models.IdempotentResponse{}
+	err = db.db.QueryRowContext(ctx, `
+		SELECT request_hash, response_status, response_headers, response_body
+		FROM idempotency_keys WHERE idempotency_key = $1
+	`, key).Scan(&stored.RequestHash, &status, &header, &stored.Body)
+	if err == sql.ErrNoRows {
+		// Purged between the two statements; the client may simply retry.
+		return &models.IdempotentResponse{RequestHash: requestHash}, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	stored.Status = int(status.Int64)
+	if header != nil {
+		if err := json.Unmarshal(header, &stored.Header); err != nil {
+			return nil, false, err
+		}
+	}
+	return stored, false, nil
+}
+
+// CompleteIdempotencyKey stores the response to replay for a claimed key.
+func (db *DB) CompleteIdempotencyKey(ctx context.Context, key string, resp *models.IdempotentResponse) error {
+	header, err := json.Marshal(resp.Header)
+	if err != nil {
+		return err
+	}
+	_, err = db.db.ExecContext(ctx, `
+		UPDATE idempotency_keys
+		SET response_status = $2, response_headers = $3, response_body = $4
+		WHERE idempotency_key = $1 AND request_hash = $5
+	`, key, resp.Status, header, resp.Body, resp.RequestHash)
+	return err
+}
+
+// ReleaseIdempotencyKey forgets a claimed key without a stored response, so a retry runs again.
+func (db *DB) ReleaseIdempotencyKey(ctx context.Context, key, requestHash string) error {
+	_, err := db.db.ExecContext(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE idempotency_key = $1 AND request_hash = $2 AND response_status IS NULL
+	`, key, requestHash)
+	return err
+}
+
+// PurgeIdempotencyKeys deletes expired keys and returns how many were removed.
+func (db *DB) PurgeIdempotencyKeys(ctx context.Context) (int64, error) {
+	res, err := db.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= LOCALTIMESTAMP")
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
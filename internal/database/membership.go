@@ -0,0 +1,309 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/models"
+)
+
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// primaryTeam resolves a user's primary team name, or ” when the user has no teams.
+const primaryTeam = `COALESCE((SELECT team_name FROM team_memberships WHERE user_id = $1 AND is_primary), '')`
+
+// addMembers adds the members to the team, creating the users that do not exist yet. Existing users
+// keep their username and activity; the IDs of those who already belong to another team are returned.
+func addMembers(ctx context.Context, tx *sql.Tx, teamName string, members []models.TeamMember) ([]string, error) {
+	inOtherTeams := []string{}
+	for _, member := range members {
+		var deleted bool
+		err := tx.QueryRowContext(ctx, "SELECT deleted_at IS NOT NULL FROM users WHERE user_id = $1 FOR UPDATE", member.UserID).Scan(&deleted)
+		switch {
+		case err == sql.ErrNoRows:
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO users (user_id, username, is_active) VALUES ($1, $2, $3)
+			`, member.UserID, member.Username, member.IsActive)
+			if err != nil {
+				return nil, err
+			}
+		case err != nil:
+			return nil, err
+		case deleted:
+			return nil, apperr.New(apperr.ErrUserDeleted, "user "+member.UserID+" has been offboarded").With("user_id", member.UserID)
+		default:
+			var inOther bool
+			err = tx.QueryRowContext(ctx, `
+				SELECT EXISTS(SELECT 1 FROM team_memberships WHERE user_id = $1 AND team_name <> $2)
+			`, member.UserID, teamName).Scan(&inOther)
+			if err != nil {
+				return nil, err
+			}
+			if inOther {
+				inOtherTeams = append(inOtherTeams, member.UserID)
+			}
+		}
+
+		if err := addMembership(ctx, tx, teamName, member.UserID, member.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	return inOtherTeams, nil
+}
+
+// addMembership adds an existing user to the team. An empty role keeps the current one for
+// existing members and defaults to member for new ones.
+func addMembership(ctx context.Context, tx *sql.Tx, teamName, userID, role string) error {
+	var inserted bool
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO team_memberships (user_id, team_name, role, is_primary)
+		VALUES ($1, $2, COALESCE(NULLIF($3, ''), 'member'),
+		        NOT EXISTS(SELECT 1 FROM team_memberships WHERE user_id = $1 AND is_primary))
+		ON CONFLICT (user_id, team_name) DO UPDATE
+		SET role = COALESCE(NULLIF($3, ''), team_memberships.role)
+		RETURNING xmax = 0
+	`, userID, teamName, role).Scan(&inserted)
+	if err != nil {
+		return err
+	}
+
+	if inserted {
+		return recordTeamChange(ctx, tx, userID, nil, &teamName)
+	}
+	return nil
+}
+
+// removeMembership drops the user's membership in the team and promotes another primary team if needed.
+func removeMembership(ctx context.Context, tx *sql.Tx, teamName, userID string) error {
+	res, err := tx.ExecContext(ctx, `
+		DELETE FROM team_memberships WHERE user_id = $1 AND team_name = $2
+	`, userID, teamName)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return apperr.NotFound("team member").With("team_name", teamName).With("user_id", userID)
+	}
+
+	if err := recordTeamChange(ctx, tx, userID, &teamName, nil); err != nil {
+		return err
+	}
+	return ensurePrimary(ctx, tx, userID)
+}
+
+// ensurePrimary promotes the user's oldest membership when they have teams but no primary one.
+func ensurePrimary(ctx context.Context, tx *sql.Tx, userID string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE team_memberships SET is_primary = true
+		WHERE user_id = $1 AND team_name = (
+			SELECT team_name FROM team_memberships WHERE user_id = $1
+			ORDER BY joined_at, team_name LIMIT 1
+		)
+		AND NOT EXISTS(SELECT 1 FROM team_memberships WHERE user_id = $1 AND is_primary)
+	`, userID)
+	return err
+}
+
+func recordTeamChange(ctx context.Context, tx *sql.Tx, userID string, fromTeam, toTeam *string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO team_membership_history (user_id, from_team_name, to_team_name)
+		VALUES ($1, $2, $3)
+	`, userID, fromTeam, toTeam)
+	return err
+}
+
+func loadUser(ctx context.Context, q querier, userID string) (*models.User, error) {
+	var user models.User
+	err := q.QueryRowContext(ctx, `
+		SELECT user_id, username, `+primaryTeam+`, is_active, deleted_at FROM users WHERE user_id = $1
+	`, userID).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.DeletedAt)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("user").With("user_id", userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.QueryContext(ctx, `
+		SELECT team_name FROM team_memberships WHERE user_id = $1 ORDER BY is_primary DESC, team_name
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	user.Teams = []string{}
+	for rows.Next() {
+		var teamName string
+		if err := rows.Scan(&teamName); err != nil {
+			return nil, err
+		}
+		user.Teams = append(user.Teams, teamName)
+	}
+
+	return &user, rows.Err()
+}
+
+// MoveUserToTeam transfers one membership (the primary one when fromTeam is empty) to another team.
+func (db *DB) MoveUserToTeam(ctx context.Context, userID, fromTeam, toTeam string) (*models.User, error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", toTeam).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apperr.NotFound("team").With("team_name", toTeam)
+	}
+
+	var id string
+	err = tx.QueryRowContext(ctx, `
+		SELECT user_id FROM users WHERE user_id = $1 AND deleted_at IS NULL FOR UPDATE
+	`, userID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, missingUser(ctx, tx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if fromTeam == "" {
+		err = tx.QueryRowContext(ctx, "SELECT "+primaryTeam, userID).Scan(&fromTeam)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if fromTeam == "" {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO team_memberships (user_id, team_name, is_primary) VALUES ($1, $2, true)
+		`, userID, toTeam)
+	} else if fromTeam != toTeam {
+		err = moveMembership(ctx, tx, userID, fromTeam, toTeam)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if fromTeam != toTeam {
+		var from *string
+		if fromTeam != "" {
+			from = &fromTeam
+		}
+		if err := recordTeamChange(ctx, tx, userID, from, &toTeam); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return loadUser(ctx, db.db, userID)
+}
+
+func moveMembership(ctx context.Context, tx *sql.Tx, userID, fromTeam, toTeam string) error {
+	var wasPrimary bool
+	err := tx.QueryRowContext(ctx, `
+		DELETE FROM team_memberships WHERE user_id = $1 AND team_name = $2 RETURNING is_primary
+	`, userID, fromTeam).Scan(&wasPrimary)
+	if err == sql.ErrNoRows {
+		return apperr.NotFound("team membership").With("team_name", fromTeam).With("user_id", userID)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO team_memberships (user_id, team_name, is_primary) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, team_name) DO UPDATE SET is_primary = team_memberships.is_primary OR EXCLUDED.is_primary
+	`, userID, toTeam, wasPrimary)
+	if err != nil {
+		return err
+	}
+
+	return ensurePrimary(ctx, tx, userID)
+}
+
+func (db *DB) SetPrimaryTeam(ctx context.Context, userID, teamName string) (*models.User, error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := setPrimaryTeam(ctx, tx, userID, teamName); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return loadUser(ctx, db.db, userID)
+}
+
+func setPrimaryTeam(ctx context.Context, tx *sql.Tx, userID, teamName string) error {
+	var isMember bool
+	err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM team_memberships WHERE user_id = $1 AND team_name = $2)
+	`, userID, teamName).Scan(&isMember)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return apperr.New(apperr.ErrNotFound, "user is not a member of the team").With("team_name", teamName).With("user_id", userID)
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE team_memberships SET is_primary = false WHERE user_id = $1 AND is_primary", userID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE team_memberships SET is_primary = true WHERE user_id = $1 AND team_name = $2
+	`, userID, teamName)
+	return err
+}
+
+func (db *DB) GetTeamHistory(ctx context.Context, userID string) ([]models.TeamChange, error) {
+	var exists bool
+	err := db.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE user_id = $1 AND deleted_at IS NULL)", userID).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, missingUser(ctx, db.db, userID)
+	}
+
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT user_id, from_team_name, to_team_name, changed_at
+		FROM team_membership_history
+		WHERE user_id = $1
+		ORDER BY changed_at, id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []models.TeamChange{}
+	for rows.Next() {
+		var change models.TeamChange
+		if err := rows.Scan(&change.UserID, &change.FromTeamName, &change.ToTeamName, &change.ChangedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, change)
+	}
+	return history, rows.Err()
+}
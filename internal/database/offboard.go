@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/models"
+)
+
+// offboardedUsername replaces the name of offboarded users; their user_id stays as a tombstone.
+const offboardedUsername = "Deleted user"
+
+// OffboardUser soft-deletes a user: the row is anonymised and tombstoned instead of deleted, so
+// their PRs and reviews stay intact and the ID cannot be reused. Their OPEN reviews are reassigned
+// like POST /pullRequest/reassign, or dropped when the team has no replacement candidate.
+func (db *DB) OffboardUser(ctx context.Context, userID string) (*models.OffboardResult, error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var deleted, wasActive bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT deleted_at IS NOT NULL, is_active FROM users WHERE user_id = $1 FOR UPDATE
+	`, userID).Scan(&deleted, &wasActive)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("user").With("user_id", userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if deleted {
+		return nil, apperr.ErrUserDeleted.With("user_id", userID)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE users SET username = $2, is_active = false, deleted_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1
+	`, userID, offboardedUsername)
+	if err != nil {
+		return nil, err
+	}
+	if wasActive {
+		if err := recordActivation(ctx, tx, userID, false); err != nil {
+			return nil, err
+		}
+	}
+	if err := redactUserChanges(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT r.pull_request_id
+		FROM pr_reviewers r
+		JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		WHERE r.user_id = $1 AND pr.status = $2
+		ORDER BY pr.created_at, pr.pull_request_id
+	`, userID, models.StatusOpen)
+	if err != nil {
+		return nil, err
+	}
+	openReviews := []string{}
+	for rows.Next() {
+		var prID string
+		if err := rows.Scan(&prID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		openReviews = append(openReviews, prID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &models.OffboardResult{
+		Reassigned: []models.ReviewerReplacement{},
+		Unassigned: []string{},
+	}
+	for _, prID := range openReviews {
+		newReviewer, err := reassignReviewer(ctx, tx, prID, userID, 0)
+		if err == nil {
+			result.Reassigned = append(result.Reassigned, models.ReviewerReplacement{PullRequestID: prID, ReplacedBy: newReviewer})
+			continue
+		}
+		if !errors.Is(err, apperr.ErrNoCandidate) {
+			return nil, err
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2", prID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := bumpVersion(ctx, tx, prID); err != nil {
+			return nil, err
+		}
+		result.Unassigned = append(result.Unassigned, prID)
+	}
+
+	rows, err = tx.QueryContext(ctx, "SELECT team_name FROM team_memberships WHERE user_id = $1", userID)
+	if err != nil {
+		return nil, err
+	}
+	teamNames := []string{}
+	for rows.Next() {
+		var teamName string
+		if err := rows.Scan(&teamName); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		teamNames = append(teamNames, teamName)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, teamName := range teamNames {
+		if err := removeMembership(ctx, tx, teamName, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM user_skills WHERE user_id = $1", userID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	user, err := loadUser(ctx, db.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	result.User = *user
+	return result, nil
+}
+
+// redactUserChanges replaces the name in the user's earlier change feed rows, which would
+// otherwise keep it readable until the retention purge.
+func redactUserChanges(ctx context.Context, tx *sql.Tx, userID string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE changes SET data = jsonb_set(data, '{username}', to_jsonb($2::TEXT))
+		WHERE entity = 'user' AND entity_key ->> 'user_id' = $1 AND data ? 'username'
+	`, userID, offboardedUsername)
+	return err
+}
+
+// missingUser explains why a write matched no live user: the ID is unknown or belongs to an offboarded user.
+func missingUser(ctx context.Context, q querier, userID string) error {
+	var deleted bool
+	err := q.QueryRowContext(ctx, "SELECT deleted_at IS NOT NULL FROM users WHERE user_id = $1", userID).Scan(&deleted)
+	if err == sql.ErrNoRows {
+		return apperr.NotFound("user").With("user_id", userID)
+	}
+	if err != nil {
+		return err
+	}
+	if deleted {
+		return apperr.ErrUserDeleted.With("user_id", userID)
+	}
+	return apperr.NotFound("user").With("user_id", userID)
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"pr-review-service/internal/models"
+)
+
+// testDB connects to TEST_DATABASE_URL and applies migrations/init.sql, skipping the test when
+// no database is configured.
+func testDB(t *testing.T) *DB {
+	t.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := New(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(db.Close)
+
+	schema, err := os.ReadFile("../../migrations/init.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.db.Exec(string(schema)); err != nil {
+		t.Fatalf("applying init.sql: %v", err)
+	}
+	return db
+}
+
+func TestOffboardUserRedactsChangeFeed(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 36)
+	userID, name := "offboard-"+suffix, "Real Name "+suffix
+	team := &models.Team{
+		TeamName: "offboard-team-" + suffix,
+		Members:  []models.TeamMember{{UserID: userID, Username: name, IsActive: true}},
+	}
+	if err := db.CreateTeam(ctx, team); err != nil {
+		t.Fatal(err)
+	}
+
+	countNamed := func() int {
+		var n int
+		err := db.db.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM changes WHERE entity = 'user' AND data ->> 'username' = $1
+		`, name).Scan(&n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+	if countNamed() == 0 {
+		t.Fatal("want the created user in the change feed")
+	}
+
+	if _, err := db.OffboardUser(ctx, userID); err != nil {
+		t.Fatal(err)
+	}
+	if n := countNamed(); n != 0 {
+		t.Fatalf("want no change rows with the old name after offboarding, got %d", n)
+	}
+}
@@ -0,0 +1,290 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// MissingTeams returns the names that are not present in the teams table.
+func (db *DB) MissingTeams(ctx context.Context, teamNames []string) ([]string, error) {
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT name FROM unnest($1::TEXT[]) AS name
+		WHERE NOT EXISTS(SELECT 1 FROM teams WHERE team_name = name)
+		ORDER BY name
+	`, pq.Array(teamNames))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	missing := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		missing = append(missing, name)
+	}
+	return missing, rows.Err()
+}
+
+// ImportOrgChart diffs the chart against the current tables and applies it in one transaction.
+// The import is additive: teams, users and memberships missing from the chart are left alone, and an
+// empty parent or role keeps the current value. With dryRun the changes are applied and rolled back,
+// so the diff is fully validated against the database without being committed.
+func (db *DB) ImportOrgChart(ctx context.Context, chart *models.OrgChart, dryRun bool) (*models.ImportDiff, error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockHierarchy(ctx, tx); err != nil {
+		return nil, err
+	}
+	diff, err := diffOrgChart(ctx, tx, chart)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkChartCycles(ctx, tx, chart); err != nil {
+		return nil, err
+	}
+
+	for _, team := range diff.TeamsCreated {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO teams (team_name) VALUES ($1)", team.TeamName); err != nil {
+			return nil, err
+		}
+	}
+	for _, team := range chart.Teams {
+		if team.ParentTeamName == "" {
+			continue
+		}
+		_, err := tx.ExecContext(ctx, `
+			UPDATE teams SET parent_team_name = $2 WHERE team_name = $1
+		`, team.TeamName, team.ParentTeamName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	membersByTeam := map[string][]models.TeamMember{}
+	teamOrder := []string{}
+	for _, user := range chart.Users {
+		if _, ok := membersByTeam[user.TeamName]; !ok {
+			teamOrder = append(teamOrder, user.TeamName)
+		}
+		membersByTeam[user.TeamName] = append(membersByTeam[user.TeamName], models.TeamMember{
+			UserID:   user.UserID,
+			Username: user.Username,
+			IsActive: user.IsActive,
+			Role:     user.Role,
+		})
+	}
+	for _, teamName := range teamOrder {
+		if _, err := addMembers(ctx, tx, teamName, membersByTeam[teamName]); err != nil {
+			return nil, err
+		}
+	}
+	for _, change := range diff.UsersUpdated {
+		if err := updateProfile(ctx, tx, change); err != nil {
+			return nil, err
+		}
+	}
+
+	if dryRun {
+		return diff, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+// updateProfile applies a username or activity change listed in the import diff.
+func updateProfile(ctx context.Context, tx *sql.Tx, change models.UserChange) error {
+	if err := setUsername(ctx, tx, change.UserID, change.Username); err != nil {
+		return err
+	}
+	if change.IsActive == change.PreviousIsActive {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, "UPDATE users SET is_active = $2 WHERE user_id = $1", change.UserID, change.IsActive)
+	if err != nil {
+		return err
+	}
+	return recordActivation(ctx, tx, change.UserID, change.IsActive)
+}
+
+// checkChartCycles rejects a chart whose parents, merged with the existing ones, would nest a
+// team under itself. The existing hierarchy is acyclic, so every cycle runs through a team the
+// chart reparents.
+func checkChartCycles(ctx context.Context, tx *sql.Tx, chart *models.OrgChart) error {
+	parents := map[string]string{}
+	rows, err := tx.QueryContext(ctx, "SELECT team_name, parent_team_name FROM teams WHERE parent_team_name IS NOT NULL")
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var name, parent string
+		if err := rows.Scan(&name, &parent); err != nil {
+			rows.Close()
+			return err
+		}
+		parents[name] = parent
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, team := range chart.Teams {
+		if team.ParentTeamName != "" {
+			parents[team.TeamName] = team.ParentTeamName
+		}
+	}
+	for _, team := range chart.Teams {
+		if team.ParentTeamName == "" {
+			continue
+		}
+		if cycle := models.TeamCycle(parents, team.TeamName); cycle != nil {
+			return apperr.New(apperr.ErrTeamCycle, "teams would form a cycle: "+strings.Join(cycle, " -> ")).With("team_name", team.TeamName)
+		}
+	}
+	return nil
+}
+
+func diffOrgChart(ctx context.Context, tx *sql.Tx, chart *models.OrgChart) (*models.ImportDiff, error) {
+	diff := &models.ImportDiff{
+		TeamsCreated:     []models.OrgTeam{},
+		TeamsReparented:  []models.TeamParentChange{},
+		UsersCreated:     []models.OrgUser{},
+		UsersUpdated:     []models.UserChange{},
+		MembershipsAdded: []models.OrgUser{},
+		RolesChanged:     []models.RoleChange{},
+	}
+
+	teamNames := make([]string, 0, len(chart.Teams))
+	for _, team := range chart.Teams {
+		teamNames = append(teamNames, team.TeamName)
+	}
+	parents := map[string]sql.NullString{}
+	rows, err := tx.QueryContext(ctx, `
+		SELECT team_name, parent_team_name FROM teams WHERE team_name = ANY($1)
+	`, pq.Array(teamNames))
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var name string
+		var parent sql.NullString
+		if err := rows.Scan(&name, &parent); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		parents[name] = parent
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, team := range chart.Teams {
+		parent, exists := parents[team.TeamName]
+		if !exists {
+			diff.TeamsCreated = append(diff.TeamsCreated, team)
+			continue
+		}
+		if team.ParentTeamName != "" && (!parent.Valid || parent.String != team.ParentTeamName) {
+			change := models.TeamParentChange{TeamName: team.TeamName, ToParentTeamName: team.ParentTeamName}
+			if parent.Valid {
+				change.FromParentTeamName = &parent.String
+			}
+			diff.TeamsReparented = append(diff.TeamsReparented, change)
+		}
+	}
+
+	userIDs := make([]string, 0, len(chart.Users))
+	for _, user := range chart.Users {
+		userIDs = append(userIDs, user.UserID)
+	}
+	existing := map[string]models.TeamMember{}
+	rows, err = tx.QueryContext(ctx, `
+		SELECT user_id, username, is_active FROM users WHERE user_id = ANY($1)
+	`, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var user models.TeamMember
+		if err := rows.Scan(&user.UserID, &user.Username, &user.IsActive); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		existing[user.UserID] = user
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	roles := map[[2]string]string{}
+	rows, err = tx.QueryContext(ctx, `
+		SELECT user_id, team_name, role FROM team_memberships WHERE user_id = ANY($1)
+	`, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var userID, teamName, role string
+		if err := rows.Scan(&userID, &teamName, &role); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		roles[[2]string{userID, teamName}] = role
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, user := range chart.Users {
+		current, exists := existing[user.UserID]
+		switch {
+		case !exists:
+			diff.UsersCreated = append(diff.UsersCreated, user)
+		case current.Username != user.Username || current.IsActive != user.IsActive:
+			diff.UsersUpdated = append(diff.UsersUpdated, models.UserChange{
+				UserID:           user.UserID,
+				Username:         user.Username,
+				IsActive:         user.IsActive,
+				PreviousUsername: current.Username,
+				PreviousIsActive: current.IsActive,
+			})
+		}
+
+		role, member := roles[[2]string{user.UserID, user.TeamName}]
+		switch {
+		case !member:
+			added := user
+			if added.Role == "" {
+				added.Role = models.RoleMember
+			}
+			diff.MembershipsAdded = append(diff.MembershipsAdded, added)
+		case user.Role != "" && user.Role != role:
+			diff.RolesChanged = append(diff.RolesChanged, models.RoleChange{
+				UserID:   user.UserID,
+				TeamName: user.TeamName,
+				FromRole: role,
+				ToRole:   user.Role,
+			})
+		}
+	}
+
+	return diff, nil
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"pr-review-service/internal/models"
+)
+
+// TryLock holds a Postgres advisory lock in an open transaction until release is called.
+func (db *DB) TryLock(ctx context.Context, name string) (release func(), ok bool, err error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock(hashtext($1))", name).Scan(&ok)
+	if err != nil || !ok {
+		tx.Rollback()
+		return nil, false, err
+	}
+
+	return func() { tx.Commit() }, true, nil
+}
+
+func (db *DB) GetPendingReminders(ctx context.Context, olderThan time.Duration) ([]models.PendingReview, error) {
+	return db.getPendingReviews(ctx, olderThan, "r.reminded_at IS NULL")
+}
+
+func (db *DB) GetPendingEscalations(ctx context.Context, olderThan time.Duration) ([]models.PendingReview, error) {
+	return db.getPendingReviews(ctx, olderThan, "r.escalated_at IS NULL")
+}
+
+// getPendingReviews reports each review with the PR's owning team, whose leads and observers are
+// notified: the team of the repository, or the author's primary team.
+func (db *DB) getPendingReviews(ctx context.Context, olderThan time.Duration, condition string) ([]models.PendingReview, error) {
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id,
+		       r.user_id, COALESCE(repo.team_name, am.team_name, ''), r.assigned_at,
+		       EXTRACT(EPOCH FROM (LOCALTIMESTAMP - r.assigned_at))::BIGINT
+		FROM pr_reviewers r
+		JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		LEFT JOIN repositories repo ON repo.repository_name = pr.repository_name
+		LEFT JOIN team_memberships am ON am.user_id = pr.author_id AND am.is_primary
+		WHERE pr.status = $1
+		  AND r.responded_at IS NULL
+		  AND r.assigned_at < LOCALTIMESTAMP - make_interval(secs => $2)
+		  AND `+condition+`
+		ORDER BY r.assigned_at
+	`, models.StatusOpen, olderThan.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []models.PendingReview{}
+	for rows.Next() {
+		var review models.PendingReview
+		if err := rows.Scan(&review.PullRequestID, &review.PullRequestName, &review.AuthorID,
+			&review.UserID, &review.TeamName, &review.AssignedAt, &review.WaitingSeconds); err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, rows.Err()
+}
+
+func (db *DB) MarkReminded(ctx context.Context, prID, userID string) error {
+	return db.markReview(ctx, prID, userID, "reminded_at")
+}
+
+func (db *DB) MarkEscalated(ctx context.Context, prID, userID string) error {
+	return db.markReview(ctx, prID, userID, "escalated_at")
+}
+
+func (db *DB) markReview(ctx context.Context, prID, userID, column string) error {
+	_, err := db.db.ExecContext(ctx, `
+		UPDATE pr_reviewers SET `+column+` = LOCALTIMESTAMP
+		WHERE pull_request_id = $1 AND user_id = $2
+	`, prID, userID)
+	return err
+}
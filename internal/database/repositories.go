@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/models"
+)
+
+func (db *DB) CreateRepository(ctx context.Context, repo *models.Repository) error {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM repositories WHERE repository_name = $1)", repo.RepositoryName).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return apperr.ErrRepositoryExists.With("repository_name", repo.RepositoryName)
+	}
+
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", repo.TeamName).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apperr.NotFound("team").With("team_name", repo.TeamName)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO repositories (repository_name, team_name, reviewer_count, strategy)
+		VALUES ($1, $2, $3, $4)
+	`, repo.RepositoryName, repo.TeamName, repo.ReviewerCount, repo.Strategy)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *DB) GetRepository(ctx context.Context, repositoryName string) (*models.Repository, error) {
+	var repo models.Repository
+	err := db.db.QueryRowContext(ctx, `
+		SELECT repository_name, team_name, reviewer_count, strategy
+		FROM repositories
+		WHERE repository_name = $1
+	`, repositoryName).Scan(&repo.RepositoryName, &repo.TeamName, &repo.ReviewerCount, &repo.Strategy)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("repository").With("repository_name", repositoryName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &repo, nil
+}
+
+// UpdateRepository applies the non-nil fields of update under a lock on the repository row, so
+// concurrent updates of different fields do not overwrite each other with stale values.
+func (db *DB) UpdateRepository(ctx context.Context, repositoryName string, update *models.RepositoryUpdate) (*models.Repository, error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var repo models.Repository
+	err = tx.QueryRowContext(ctx, `
+		SELECT repository_name, team_name, reviewer_count, strategy
+		FROM repositories
+		WHERE repository_name = $1
+		FOR UPDATE
+	`, repositoryName).Scan(&repo.RepositoryName, &repo.TeamName, &repo.ReviewerCount, &repo.Strategy)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("repository").With("repository_name", repositoryName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if update.TeamName != nil && *update.TeamName != repo.TeamName {
+		var exists bool
+		err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", *update.TeamName).Scan(&exists)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, apperr.NotFound("team").With("team_name", *update.TeamName)
+		}
+		repo.TeamName = *update.TeamName
+	}
+	if update.ReviewerCount != nil {
+		repo.ReviewerCount = *update.ReviewerCount
+	}
+	if update.Strategy != nil {
+		repo.Strategy = *update.Strategy
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE repositories
+		SET team_name = $2, reviewer_count = $3, strategy = $4
+		WHERE repository_name = $1
+	`, repo.RepositoryName, repo.TeamName, repo.ReviewerCount, repo.Strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
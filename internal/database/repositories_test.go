@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"pr-review-service/internal/models"
+)
+
+func TestUpdateRepositoryConcurrentFields(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 36)
+	team := &models.Team{TeamName: "repo-team-" + suffix, Members: []models.TeamMember{}}
+	if err := db.CreateTeam(ctx, team); err != nil {
+		t.Fatal(err)
+	}
+	repo := &models.Repository{
+		RepositoryName: "repo-" + suffix,
+		TeamName:       team.TeamName,
+		ReviewerCount:  models.DefaultReviewerCount,
+		Strategy:       models.StrategyRandom,
+	}
+	if err := db.CreateRepository(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each update changes a different field; with a read-modify-write outside the row lock one
+	// would write back the other's stale value.
+	count, strategy := 5, models.StrategyLeastLoaded
+	updates := []models.RepositoryUpdate{{ReviewerCount: &count}, {Strategy: &strategy}}
+	var wg sync.WaitGroup
+	errs := make([]error, len(updates))
+	for i := range updates {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = db.UpdateRepository(ctx, repo.RepositoryName, &updates[i])
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := db.GetRepository(ctx, repo.RepositoryName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ReviewerCount != count || got.Strategy != strategy {
+		t.Fatalf("want reviewer_count %d and strategy %s, got %d and %s", count, strategy, got.ReviewerCount, got.Strategy)
+	}
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/models"
+)
+
+func (db *DB) SetMemberRole(ctx context.Context, teamName, userID, role string) (*models.Team, error) {
+	res, err := db.db.ExecContext(ctx, `
+		UPDATE team_memberships SET role = $3
+		WHERE team_name = $1 AND user_id = $2
+	`, teamName, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, apperr.NotFound("team member").With("team_name", teamName).With("user_id", userID)
+	}
+
+	return db.GetTeam(ctx, teamName, false)
+}
+
+// GetTeamLeads returns the active leads of the team, falling back to the nearest ancestor team that has any.
+func (db *DB) GetTeamLeads(ctx context.Context, teamName string) ([]string, error) {
+	ancestors, err := teamAncestors(ctx, db.db, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, team := range append([]string{teamName}, ancestors...) {
+		leads, err := db.teamMembersWithRole(ctx, team, models.RoleLead)
+		if err != nil {
+			return nil, err
+		}
+		if len(leads) > 0 {
+			return leads, nil
+		}
+	}
+	return []string{}, nil
+}
+
+func (db *DB) GetTeamObservers(ctx context.Context, teamName string) ([]string, error) {
+	return db.teamMembersWithRole(ctx, teamName, models.RoleObserver)
+}
+
+func (db *DB) teamMembersWithRole(ctx context.Context, teamName, role string) ([]string, error) {
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT m.user_id
+		FROM team_memberships m
+		JOIN users u ON u.user_id = m.user_id
+		WHERE m.team_name = $1 AND m.role = $2 AND u.is_active = true
+		ORDER BY m.user_id
+	`, teamName, role)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, rows.Err()
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/models"
+)
+
+func (db *DB) SetTeamReviewSLA(ctx context.Context, teamName string, hours *int) (*models.Team, error) {
+	if err := setTeamReviewSLA(ctx, db.db, teamName, hours); err != nil {
+		return nil, err
+	}
+
+	return db.GetTeam(ctx, teamName, false)
+}
+
+func setTeamReviewSLA(ctx context.Context, q querier, teamName string, hours *int) error {
+	res, err := q.ExecContext(ctx, `
+		UPDATE teams SET review_sla_hours = $2 WHERE team_name = $1
+	`, teamName, hours)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return apperr.NotFound("team").With("team_name", teamName)
+	}
+	return nil
+}
+
+func (db *DB) RespondToReview(ctx context.Context, prID, userID string) (*models.ReviewerAssignment, error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// FOR SHARE keeps the PR from being merged before the response is stamped.
+	var status string
+	err = tx.QueryRowContext(ctx, "SELECT status FROM pull_requests WHERE pull_request_id = $1 FOR SHARE", prID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("PR").With("pull_request_id", prID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if status == models.StatusMerged {
+		return nil, apperr.New(apperr.ErrPRMerged, "cannot respond to review on merged PR").With("pull_request_id", prID)
+	}
+
+	var review models.ReviewerAssignment
+	err = tx.QueryRowContext(ctx, `
+		UPDATE pr_reviewers
+		SET responded_at = COALESCE(responded_at, LOCALTIMESTAMP)
+		WHERE pull_request_id = $1 AND user_id = $2
+		RETURNING pull_request_id, user_id, assigned_at, responded_at
+	`, prID, userID).Scan(&review.PullRequestID, &review.UserID, &review.AssignedAt, &review.RespondedAt)
+	if err == sql.ErrNoRows {
+		return nil, apperr.ErrNotAssigned.With("pull_request_id", prID).With("user_id", userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// GetOverduePRs measures each PR against the SLA of its owning team: the team of its repository,
+// or the author's primary team for PRs without one.
+func (db *DB) GetOverduePRs(ctx context.Context, teamName string) ([]models.OverduePR, error) {
+	rows, err := db.db.QueryContext(ctx, teamScope("$2", "TRUE")+`
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status,
+		       t.team_name, t.review_sla_hours,
+		       r.user_id, r.assigned_at,
+		       EXTRACT(EPOCH FROM (LOCALTIMESTAMP - r.assigned_at))::BIGINT
+		FROM pull_requests pr
+		LEFT JOIN repositories repo ON repo.repository_name = pr.repository_name
+		LEFT JOIN team_memberships am ON am.user_id = pr.author_id AND am.is_primary
+		JOIN teams t ON t.team_name = COALESCE(repo.team_name, am.team_name)
+		JOIN pr_reviewers r ON r.pull_request_id = pr.pull_request_id
+		WHERE pr.status = $1
+		  AND t.review_sla_hours IS NOT NULL
+		  AND r.responded_at IS NULL
+		  AND r.assigned_at + make_interval(hours => t.review_sla_hours) < LOCALTIMESTAMP
+		  AND ($2 = '' OR t.team_name IN (SELECT team_name FROM scope))
+		ORDER BY pr.created_at, pr.pull_request_id, r.assigned_at
+	`, models.StatusOpen, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prs := []models.OverduePR{}
+	for rows.Next() {
+		var pr models.OverduePR
+		var reviewer models.OverdueReviewer
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status,
+			&pr.TeamName, &pr.ReviewSLAHours,
+			&reviewer.UserID, &reviewer.AssignedAt, &reviewer.WaitingSeconds); err != nil {
+			return nil, err
+		}
+
+		if n := len(prs); n > 0 && prs[n-1].PullRequestID == pr.PullRequestID {
+			prs[n-1].OverdueReviewers = append(prs[n-1].OverdueReviewers, reviewer)
+			continue
+		}
+		pr.OverdueReviewers = []models.OverdueReviewer{reviewer}
+		prs = append(prs, pr)
+	}
+
+	return prs, rows.Err()
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"pr-review-service/internal/models"
+)
+
+func loadInheritedReviewers(ctx context.Context, tx *sql.Tx, parentID, authorID string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT r.user_id
+		FROM pr_reviewers r
+		JOIN users u ON u.user_id = r.user_id
+		WHERE r.pull_request_id = $1 AND r.user_id != $2 AND u.is_active = true
+		  AND EXISTS(SELECT 1 FROM team_memberships m WHERE m.user_id = r.user_id AND m.role <> 'observer')
+		ORDER BY r.assigned_at
+	`, parentID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviewers := []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, userID)
+	}
+	return reviewers, rows.Err()
+}
+
+// GetPRStack returns every PR in the stack containing prID, from the root down.
+func (db *DB) GetPRStack(ctx context.Context, prID string) ([]models.StackEntry, error) {
+	rows, err := db.db.QueryContext(ctx, `
+		WITH RECURSIVE ancestors AS (
+			SELECT pull_request_id, parent_pull_request_id
+			FROM pull_requests WHERE pull_request_id = $1
+			UNION ALL
+			SELECT p.pull_request_id, p.parent_pull_request_id
+			FROM pull_requests p
+			JOIN ancestors a ON p.pull_request_id = a.parent_pull_request_id
+		), stack AS (
+			SELECT pull_request_id, 0 AS depth
+			FROM ancestors WHERE parent_pull_request_id IS NULL
+			UNION ALL
+			SELECT p.pull_request_id, s.depth + 1
+			FROM pull_requests p
+			JOIN stack s ON p.parent_pull_request_id = s.pull_request_id
+		)
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status,
+		       COALESCE(pr.parent_pull_request_id, ''), s.depth
+		FROM stack s
+		JOIN pull_requests pr ON pr.pull_request_id = s.pull_request_id
+		ORDER BY s.depth, pr.created_at, pr.pull_request_id
+	`, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stack := []models.StackEntry{}
+	for rows.Next() {
+		var entry models.StackEntry
+		if err := rows.Scan(&entry.PullRequestID, &entry.PullRequestName, &entry.AuthorID, &entry.Status,
+			&entry.ParentPullRequestID, &entry.Depth); err != nil {
+			return nil, err
+		}
+		stack = append(stack, entry)
+	}
+	return stack, rows.Err()
+}
@@ -0,0 +1,268 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/models"
+)
+
+func (db *DB) AddTeamMembers(ctx context.Context, teamName string, members []models.TeamMember) (*models.Team, error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", teamName).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apperr.NotFound("team").With("team_name", teamName)
+	}
+
+	inOtherTeams, err := addMembers(ctx, tx, teamName, members)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	team, err := db.GetTeam(ctx, teamName, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(inOtherTeams) > 0 {
+		team.MembersInOtherTeams = inOtherTeams
+	}
+	return team, nil
+}
+
+func (db *DB) RemoveTeamMember(ctx context.Context, teamName, userID string) (*models.Team, error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := removeMembership(ctx, tx, teamName, userID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return db.GetTeam(ctx, teamName, false)
+}
+
+// UpdateTeamMembers adds and removes memberships of existing users in one transaction.
+// Unknown or offboarded users and removals of non-members yield NOT_FOUND.
+func (db *DB) UpdateTeamMembers(ctx context.Context, teamName string, add, remove []string) (*models.Team, error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", teamName).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apperr.NotFound("team").With("team_name", teamName)
+	}
+
+	for _, userID := range add {
+		err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE user_id = $1 AND deleted_at IS NULL)", userID).Scan(&exists)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, apperr.NotFound("user "+userID).With("user_id", userID)
+		}
+		if err := addMembership(ctx, tx, teamName, userID, ""); err != nil {
+			return nil, err
+		}
+	}
+	for _, userID := range remove {
+		if err := removeMembership(ctx, tx, teamName, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return db.GetTeam(ctx, teamName, false)
+}
+
+func (db *DB) RenameTeam(ctx context.Context, teamName, newTeamName string) (*models.Team, error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := renameTeam(ctx, tx, teamName, newTeamName); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return db.GetTeam(ctx, newTeamName, false)
+}
+
+func renameTeam(ctx context.Context, tx *sql.Tx, teamName, newTeamName string) error {
+	var exists bool
+	err := tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", newTeamName).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return apperr.New(apperr.ErrTeamExists, "new_team_name already exists").With("team_name", newTeamName)
+	}
+
+	res, err := tx.ExecContext(ctx, "UPDATE teams SET team_name = $2 WHERE team_name = $1", teamName, newTeamName)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return apperr.NotFound("team").With("team_name", teamName)
+	}
+	return nil
+}
+
+// UpdateTeam applies the review SLA, parent and name changes of update in one transaction, so
+// a failing step (e.g. a cycle or a taken name) leaves the team unchanged.
+func (db *DB) UpdateTeam(ctx context.Context, teamName string, update *models.TeamUpdate) (*models.Team, error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockTeam(ctx, tx, teamName); err != nil {
+		return nil, err
+	}
+	if update.SetReviewSLA {
+		if err := setTeamReviewSLA(ctx, tx, teamName, update.ReviewSLAHours); err != nil {
+			return nil, err
+		}
+	}
+	if update.SetParent {
+		if err := setTeamParent(ctx, tx, teamName, update.ParentTeamName); err != nil {
+			return nil, err
+		}
+	}
+	if update.TeamName != nil && *update.TeamName != teamName {
+		if err := renameTeam(ctx, tx, teamName, *update.TeamName); err != nil {
+			return nil, err
+		}
+		teamName = *update.TeamName
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return db.GetTeam(ctx, teamName, false)
+}
+
+// lockTeam locks the team row for the rest of the transaction.
+func lockTeam(ctx context.Context, tx *sql.Tx, teamName string) error {
+	var name string
+	err := tx.QueryRowContext(ctx, "SELECT team_name FROM teams WHERE team_name = $1 FOR UPDATE", teamName).Scan(&name)
+	if err == sql.ErrNoRows {
+		return apperr.NotFound("team").With("team_name", teamName)
+	}
+	return err
+}
+
+// DeleteTeam drops only the team's memberships, so its users and their PRs and reviews survive.
+// A team that owns repositories is never deleted, even with force: its repositories have to be
+// moved to another team first (POST /repository/update).
+func (db *DB) DeleteTeam(ctx context.Context, teamName string, force bool) error {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", teamName).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apperr.NotFound("team").With("team_name", teamName)
+	}
+
+	var hasRepos bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM repositories WHERE team_name = $1)", teamName).Scan(&hasRepos)
+	if err != nil {
+		return err
+	}
+	if hasRepos {
+		return apperr.ErrTeamHasRepos.With("team_name", teamName)
+	}
+
+	if !force {
+		var hasOpenReviews bool
+		err = tx.QueryRowContext(ctx, `
+			SELECT EXISTS(
+				SELECT 1 FROM pr_reviewers r
+				JOIN team_memberships m ON m.user_id = r.user_id
+				JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+				WHERE m.team_name = $1 AND pr.status = $2
+			)
+		`, teamName, models.StatusOpen).Scan(&hasOpenReviews)
+		if err != nil {
+			return err
+		}
+		if hasOpenReviews {
+			return apperr.ErrTeamHasOpenReviews.With("team_name", teamName)
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		INSERT INTO team_membership_history (user_id, from_team_name, to_team_name)
+		SELECT user_id, team_name, NULL FROM team_memberships WHERE team_name = $1
+		RETURNING user_id
+	`, teamName)
+	if err != nil {
+		return err
+	}
+	members := []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			rows.Close()
+			return err
+		}
+		members = append(members, userID)
+	}
+	rows.Close()
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM teams WHERE team_name = $1", teamName)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range members {
+		if err := ensurePrimary(ctx, tx, userID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,247 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+func (db *DB) GetUser(ctx context.Context, userID string) (*models.User, error) {
+	return loadUser(ctx, db.db, userID)
+}
+
+// CreateUser adds a user that does not belong to any team yet.
+func (db *DB) CreateUser(ctx context.Context, userID, username string, isActive bool) (*models.User, error) {
+	res, err := db.db.ExecContext(ctx, `
+		INSERT INTO users (user_id, username, is_active) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO NOTHING
+	`, userID, username, isActive)
+	if err != nil {
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		// The row exists, so missingUser reports either an offboarded user or a failed lookup.
+		if err := missingUser(ctx, db.db, userID); !errors.Is(err, apperr.ErrNotFound) {
+			return nil, err
+		}
+		return nil, apperr.ErrUserExists.With("user_id", userID)
+	}
+
+	return loadUser(ctx, db.db, userID)
+}
+
+func (db *DB) SetUsername(ctx context.Context, userID, username string) (*models.User, error) {
+	if err := setUsername(ctx, db.db, userID, username); err != nil {
+		return nil, err
+	}
+
+	return loadUser(ctx, db.db, userID)
+}
+
+func setUsername(ctx context.Context, q querier, userID, username string) error {
+	res, err := q.ExecContext(ctx, `
+		UPDATE users SET username = $2 WHERE user_id = $1 AND deleted_at IS NULL
+	`, userID, username)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return missingUser(ctx, q, userID)
+	}
+	return nil
+}
+
+// UpdateUser applies the username, activity, primary team and skills changes of update in one
+// transaction, so a failing step (e.g. a team the user is not in) leaves the user unchanged.
+func (db *DB) UpdateUser(ctx context.Context, userID string, update *models.UserUpdate) (*models.UserProfile, error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = tx.QueryRowContext(ctx, `
+		SELECT user_id FROM users WHERE user_id = $1 AND deleted_at IS NULL FOR UPDATE
+	`, userID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, missingUser(ctx, tx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Username != nil {
+		if err := setUsername(ctx, tx, userID, *update.Username); err != nil {
+			return nil, err
+		}
+	}
+	if update.IsActive != nil {
+		if err := setUserActive(ctx, tx, userID, *update.IsActive); err != nil {
+			return nil, err
+		}
+	}
+	if update.PrimaryTeamName != nil {
+		if err := setPrimaryTeam(ctx, tx, userID, *update.PrimaryTeamName); err != nil {
+			return nil, err
+		}
+	}
+	if update.Skills != nil {
+		if err := setUserSkills(ctx, tx, userID, *update.Skills); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return db.GetUserProfile(ctx, userID)
+}
+
+func (db *DB) GetUserProfile(ctx context.Context, userID string) (*models.UserProfile, error) {
+	user, err := loadUser(ctx, db.db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := models.UserProfile{User: *user}
+
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT team_name, role, is_primary FROM team_memberships
+		WHERE user_id = $1
+		ORDER BY is_primary DESC, team_name
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profile.Memberships = []models.TeamMembership{}
+	for rows.Next() {
+		var membership models.TeamMembership
+		if err := rows.Scan(&membership.TeamName, &membership.Role, &membership.IsPrimary); err != nil {
+			return nil, err
+		}
+		profile.Memberships = append(profile.Memberships, membership)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	profile.Skills = []string{}
+	err = db.db.QueryRowContext(ctx, `
+		SELECT
+			ARRAY(SELECT skill FROM user_skills WHERE user_id = $1 ORDER BY skill),
+			COUNT(*) FILTER (WHERE pr.status = $2),
+			COUNT(*) FILTER (WHERE pr.status = $2 AND r.responded_at IS NULL)
+		FROM pr_reviewers r
+		JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		WHERE r.user_id = $1
+	`, userID, models.StatusOpen).Scan(pq.Array(&profile.Skills), &profile.ReviewLoad.OpenReviews, &profile.ReviewLoad.PendingReviews)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+// userFilter matches live (not offboarded) users by team scope ($1, $2), activity ($3), skill ($4) and username search
+// ($5, escaped with escapeLike).
+const userFilter = `
+	WHERE u.deleted_at IS NULL
+	AND ($1 = '' OR EXISTS(
+		SELECT 1 FROM team_memberships m
+		WHERE m.user_id = u.user_id AND m.team_name IN (SELECT team_name FROM scope)
+	))
+	AND ($3::BOOLEAN IS NULL OR u.is_active = $3)
+	AND ($4 = '' OR EXISTS(SELECT 1 FROM user_skills s WHERE s.user_id = u.user_id AND s.skill = $4))
+	AND ($5 = '' OR u.username ILIKE '%' || $5 || '%' ESCAPE '\')`
+
+// likeEscaper escapes the LIKE wildcards, so a search for "50%" or "a_b" matches them literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+func (db *DB) ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, int, error) {
+	var total int
+	err := db.db.QueryRowContext(ctx, teamScope("$1", "$2")+`
+		SELECT COUNT(*) FROM users u `+userFilter,
+		filter.TeamName, filter.IncludeSubteams, filter.IsActive, filter.Skill, escapeLike(filter.Search)).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := db.db.QueryContext(ctx, teamScope("$1", "$2")+`
+		SELECT u.user_id, u.username, u.is_active,
+		       COALESCE((SELECT team_name FROM team_memberships WHERE user_id = u.user_id AND is_primary), ''),
+		       ARRAY(SELECT team_name FROM team_memberships WHERE user_id = u.user_id ORDER BY is_primary DESC, team_name)
+		FROM users u `+userFilter+`
+		ORDER BY u.username, u.user_id
+		LIMIT $6 OFFSET $7
+	`, filter.TeamName, filter.IncludeSubteams, filter.IsActive, filter.Skill, escapeLike(filter.Search), filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		var user models.User
+		user.Teams = []string{}
+		if err := rows.Scan(&user.UserID, &user.Username, &user.IsActive, &user.TeamName, pq.Array(&user.Teams)); err != nil {
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+
+	return users, total, rows.Err()
+}
+
+func (db *DB) SetUserSkills(ctx context.Context, userID string, skills []string) (*models.UserProfile, error) {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := setUserSkills(ctx, tx, userID, skills); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return db.GetUserProfile(ctx, userID)
+}
+
+func setUserSkills(ctx context.Context, tx *sql.Tx, userID string, skills []string) error {
+	var exists bool
+	err := tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE user_id = $1 AND deleted_at IS NULL)", userID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return missingUser(ctx, tx, userID)
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM user_skills WHERE user_id = $1", userID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO user_skills (user_id, skill)
+		SELECT $1, skill FROM unnest($2::TEXT[]) AS skill
+		ON CONFLICT DO NOTHING
+	`, userID, pq.Array(skills))
+	return err
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"pr-review-service/internal/models"
+)
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		search string
+		want   string
+	}{
+		{search: "", want: ""},
+		{search: "alice", want: "alice"},
+		{search: "50%", want: `50\%`},
+		{search: "a_b", want: `a\_b`},
+		{search: `dom\user`, want: `dom\\user`},
+		{search: `%_\`, want: `\%\_\\`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.search, func(t *testing.T) {
+			if got := escapeLike(tt.search); got != tt.want {
+				t.Fatalf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestListUsersSearchIsLiteral(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 36)
+	team := &models.Team{
+		TeamName: "search-team-" + suffix,
+		Members: []models.TeamMember{
+			{UserID: "search-1-" + suffix, Username: "a_b " + suffix, IsActive: true},
+			{UserID: "search-2-" + suffix, Username: "axb " + suffix, IsActive: true},
+			{UserID: "search-3-" + suffix, Username: "100% " + suffix, IsActive: true},
+			{UserID: "search-4-" + suffix, Username: "1000 " + suffix, IsActive: true},
+		},
+	}
+	if err := db.CreateTeam(ctx, team); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, search := range []string{"a_b", "100%"} {
+		t.Run(search, func(t *testing.T) {
+			users, total, err := db.ListUsers(ctx, models.UserFilter{TeamName: team.TeamName, Search: search, Limit: 10})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if total != 1 || len(users) != 1 || users[0].Username != search+" "+suffix {
+				t.Fatalf("want only %q, got total %d: %+v", search+" "+suffix, total, users)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Package events streams assignment events (PR created, reviewers assigned, reassigned, merged,
+// user activation changes) as Server-Sent Events at /events/stream, so that clients such as IDE
+// plugins learn about new reviews without polling /users/getReview.
+//
+// Events are written to the events table in the transaction of the change itself. A Broker polls
+// the table once per interval for all connected clients and fans new events out in memory; a
+// client reconnecting with Last-Event-ID first reads what it missed from the table.
+package events
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"pr-review-service/internal/database"
+	"pr-review-service/internal/models"
+)
+
+const (
+	Path = "/events/stream"
+
+	pollInterval = time.Second
+	pageSize     = 500
+	// subscriberBuffer is how many events a slow client may lag behind before it is
+	// disconnected; it then resumes from the table with Last-Event-ID.
+	subscriberBuffer = 256
+)
+
+type subscriber struct {
+	filter models.EventFilter
+	// events is closed when the subscriber is dropped for falling behind.
+	events chan *models.Event
+}
+
+type Broker struct {
+	db *database.DB
+
+	mu          sync.Mutex
+	ready       bool
+	lastID      int64
+	subscribers map[*subscriber]struct{}
+}
+
+func New(db *database.DB) *Broker {
+	return &Broker{db: db, subscribers: make(map[*subscriber]struct{})}
+}
+
+// Run polls for new events until ctx is done.
+func (b *Broker) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		b.poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (b *Broker) poll(ctx context.Context) {
+	b.mu.Lock()
+	idle := len(b.subscribers) == 0
+	b.mu.Unlock()
+
+	// Without subscribers only the position is kept current, so that a new client without
+	// Last-Event-ID starts at the latest event rather than at wherever polling stopped.
+	if idle {
+		id, err := b.db.LatestEventID(ctx)
+		if err != nil {
+			log.Printf("Event broker: error loading latest event: %v", err)
+			return
+		}
+		b.mu.Lock()
+		if len(b.subscribers) == 0 {
+			b.lastID, b.ready = id, true
+		}
+		b.mu.Unlock()
+		return
+	}
+
+	for {
+		b.mu.Lock()
+		after := b.lastID
+		b.mu.Unlock()
+
+		events, err := b.db.EventsAfter(ctx, after, models.EventFilter{}, pageSize)
+		if err != nil {
+			log.Printf("Event broker: error loading events: %v", err)
+			return
+		}
+		b.dispatch(events)
+		if len(events) < pageSize {
+			return
+		}
+	}
+}
+
+func (b *Broker) dispatch(events []*models.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, event := range events {
+		for sub := range b.subscribers {
+			if !sub.filter.Matches(event) {
+				continue
+			}
+			select {
+			case sub.events <- event:
+			default:
+				delete(b.subscribers, sub)
+				close(sub.events)
+			}
+		}
+		b.lastID = event.ID
+	}
+}
+
+// subscribe registers a client for live events after the returned position; ok is false until
+// the broker has read the position from the database.
+func (b *Broker) subscribe(filter models.EventFilter) (sub *subscriber, position int64, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.ready {
+		return nil, 0, false
+	}
+	sub = &subscriber{filter: filter, events: make(chan *models.Event, subscriberBuffer)}
+	b.subscribers[sub] = struct{}{}
+	return sub, b.lastID, true
+}
+
+func (b *Broker) unsubscribe(sub *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.events)
+	}
+}
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"pr-review-service/internal/models"
+	"pr-review-service/internal/validate"
+)
+
+const (
+	heartbeatInterval = 15 * time.Second
+	// retryMillis tells EventSource clients how long to wait before reconnecting.
+	retryMillis = 3000
+)
+
+// ServeHTTP streams events matching the team_name and user_id query parameters. Without
+// Last-Event-ID (or the last_event_id query parameter, for clients that cannot set headers) the
+// stream starts with the next event.
+func (b *Broker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := models.EventFilter{TeamName: query.Get("team_name"), UserID: query.Get("user_id")}
+	var v validate.Validator
+	v.MaxLength("team_name", filter.TeamName, validate.MaxNameLength)
+	v.MaxLength("user_id", filter.UserID, validate.MaxIDLength)
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = query.Get("last_event_id")
+	}
+	var cursor int64
+	if lastEventID != "" {
+		var err error
+		cursor, err = strconv.ParseInt(lastEventID, 10, 64)
+		v.Check(err == nil && cursor >= 0, "Last-Event-ID", "must be an event id")
+	}
+	if !v.Valid() {
+		respondError(w, http.StatusBadRequest, models.ErrorDetail{Code: models.ErrValidation, Message: "request validation failed", Fields: v.Fields})
+		return
+	}
+
+	sub, position, ok := b.subscribe(filter)
+	if !ok {
+		w.Header().Set("Retry-After", "5")
+		respondError(w, http.StatusServiceUnavailable, models.ErrorDetail{Code: models.ErrUnavailable, Message: "event stream is starting, retry shortly"})
+		return
+	}
+	defer b.unsubscribe(sub)
+	if lastEventID == "" {
+		cursor = position
+	}
+
+	// The server's WriteTimeout is meant for ordinary requests, not for a stream.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("Event stream: cannot lift write deadline: %v", err)
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", retryMillis)
+
+	// Catch up from the table up to the position live delivery starts after.
+catchUp:
+	for cursor < position {
+		events, err := b.db.EventsAfter(r.Context(), cursor, filter, pageSize)
+		if err != nil {
+			log.Printf("Event stream: error loading missed events: %v", err)
+			return
+		}
+		for _, event := range events {
+			if event.ID > position {
+				break catchUp
+			}
+			if !writeEvent(w, event) {
+				return
+			}
+			cursor = event.ID
+		}
+		if len(events) < pageSize {
+			break
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-sub.events:
+			if !ok {
+				return
+			}
+			if event.ID <= cursor {
+				continue
+			}
+			if !writeEvent(w, event) {
+				return
+			}
+			cursor = event.ID
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event *models.Event) bool {
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Error encoding event %d: %v", event.ID, err)
+		return false
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err == nil
+}
+
+func respondError(w http.ResponseWriter, status int, detail models.ErrorDetail) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{Error: detail})
+}
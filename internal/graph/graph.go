@@ -0,0 +1,123 @@
+// Package graph serves a read-only GraphQL API at /graphql over teams, users, pull requests and
+// reviews, so that dashboards can fetch nested data (team → members → their reviews → PR authors)
+// in one request.
+//
+// Every request gets its own set of dataloaders: resolvers at the same depth load their keys
+// through a loader, which turns them into a single "= ANY($1)" query instead of one query per
+// parent object.
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"pr-review-service/internal/database"
+	"pr-review-service/internal/models"
+)
+
+const (
+	Path = "/graphql"
+
+	// maxParallelism bounds the resolvers run concurrently per request; it is also the number
+	// of sibling list elements whose keys end up in one batch.
+	maxParallelism = 100
+	maxDepth       = 12
+	maxQueryLength = 16 << 10
+)
+
+type Handler struct {
+	db     *database.DB
+	schema *graphql.Schema
+}
+
+func New(db *database.DB) *Handler {
+	return &Handler{
+		db: db,
+		schema: graphql.MustParseSchema(schema, &root{db: db},
+			graphql.MaxParallelism(maxParallelism),
+			graphql.MaxDepth(maxDepth),
+			graphql.MaxQueryLength(maxQueryLength),
+		),
+	}
+}
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// ServeHTTP executes a query sent as a JSON POST body or, for GET, in the query string.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	switch r.Method {
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, models.ErrInvalidRequest, "Invalid request body")
+			return
+		}
+	case http.MethodGet:
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				respondError(w, http.StatusBadRequest, models.ErrInvalidRequest, "Invalid variables")
+				return
+			}
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if req.Query == "" {
+		respondError(w, http.StatusBadRequest, models.ErrInvalidRequest, "query is required")
+		return
+	}
+
+	ctx := withLoaders(r.Context(), h.db)
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("Error encoding GraphQL response: %v", err)
+	}
+}
+
+func respondError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ErrorResponse{Error: models.ErrorDetail{Code: code, Message: message}})
+}
+
+// resolverError is reported in the GraphQL errors list with the API error code as extensions.code.
+type resolverError struct {
+	code    string
+	message string
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// dbError hides database failures from clients; they are logged with the failed operation.
+func dbError(action string, err error) error {
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+	log.Printf("GraphQL error %s: %v", action, err)
+	if database.IsUnavailable(err) {
+		return &resolverError{code: models.ErrUnavailable, message: "database is temporarily unavailable"}
+	}
+	return &resolverError{code: models.ErrInternal, message: "internal server error"}
+}
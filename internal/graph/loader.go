@@ -0,0 +1,111 @@
+package graph
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// batchWait is how long a loader collects keys before querying. graphql-go resolves list
+	// elements concurrently (up to maxParallelism), so sibling resolvers enqueue their keys
+	// within this window and share one query.
+	batchWait = 2 * time.Millisecond
+	// maxBatch caps the number of keys in one query.
+	maxBatch = 500
+)
+
+// loader is a per-request dataloader: Load calls made close together are coalesced into one
+// fetch, and every key is fetched at most once per request.
+type loader[K comparable, V any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	cache   map[K]*batch[K, V]
+	pending *batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	keys   []K
+	done   chan struct{}
+	values map[K]V
+	err    error
+}
+
+func newLoader[K comparable, V any](ctx context.Context, fetch func(context.Context, []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{ctx: ctx, fetch: fetch, cache: make(map[K]*batch[K, V])}
+}
+
+// Load returns the value for key, or the zero value if fetch returned none.
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	return l.wait(ctx, key, l.enqueue(key))
+}
+
+// LoadMany loads keys in the same batch and returns their values in order.
+func (l *loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, error) {
+	batches := make([]*batch[K, V], len(keys))
+	for i, key := range keys {
+		batches[i] = l.enqueue(key)
+	}
+	values := make([]V, len(keys))
+	for i, key := range keys {
+		value, err := l.wait(ctx, key, batches[i])
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// enqueue returns the batch that fetches key, adding key to the pending batch if it was not
+// requested before.
+func (l *loader[K, V]) enqueue(key K) *batch[K, V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.cache[key]; ok {
+		return b
+	}
+	if l.pending == nil {
+		l.pending = &batch[K, V]{done: make(chan struct{})}
+		pending := l.pending
+		time.AfterFunc(batchWait, func() { l.dispatch(pending) })
+	}
+	b := l.pending
+	b.keys = append(b.keys, key)
+	l.cache[key] = b
+	if len(b.keys) >= maxBatch {
+		l.pending = nil
+		go l.run(b)
+	}
+	return b
+}
+
+func (l *loader[K, V]) wait(ctx context.Context, key K, b *batch[K, V]) (V, error) {
+	select {
+	case <-b.done:
+		return b.values[key], b.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// dispatch runs b when its wait is over, unless it already ran because it filled up.
+func (l *loader[K, V]) dispatch(b *batch[K, V]) {
+	l.mu.Lock()
+	if l.pending != b {
+		l.mu.Unlock()
+		return
+	}
+	l.pending = nil
+	l.mu.Unlock()
+	l.run(b)
+}
+
+func (l *loader[K, V]) run(b *batch[K, V]) {
+	b.values, b.err = l.fetch(l.ctx, b.keys)
+	close(b.done)
+}
@@ -0,0 +1,206 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingFetch returns each key doubled and records the keys of every call.
+type recordingFetch struct {
+	mu    sync.Mutex
+	calls [][]int
+}
+
+func (f *recordingFetch) fetch(ctx context.Context, keys []int) (map[int]int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, append([]int(nil), keys...))
+	values := make(map[int]int, len(keys))
+	for _, key := range keys {
+		values[key] = key * 2
+	}
+	return values, nil
+}
+
+// sortedCalls returns the recorded calls with their keys sorted, as concurrent loads enqueue
+// in no particular order.
+func (f *recordingFetch) sortedCalls() [][]int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	calls := make([][]int, len(f.calls))
+	for i, keys := range f.calls {
+		calls[i] = append([]int(nil), keys...)
+		sort.Ints(calls[i])
+	}
+	return calls
+}
+
+func TestLoaderMergesConcurrentLoads(t *testing.T) {
+	ctx := context.Background()
+	f := &recordingFetch{}
+	l := newLoader(ctx, f.fetch)
+
+	const n = 10
+	got := make([]int, n)
+	errs := make([]error, n)
+	// Release the goroutines together so all of them enqueue within one batch window.
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			got[i], errs[i] = l.Load(ctx, i)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		if errs[i] != nil {
+			t.Fatalf("load %d: %v", i, errs[i])
+		}
+		if got[i] != i*2 {
+			t.Fatalf("load %d: want %d, got %d", i, i*2, got[i])
+		}
+	}
+	want := [][]int{{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}}
+	if calls := f.sortedCalls(); !reflect.DeepEqual(calls, want) {
+		t.Fatalf("want one fetch %v, got %v", want, calls)
+	}
+}
+
+func TestLoaderDeduplicatesKeys(t *testing.T) {
+	ctx := context.Background()
+	f := &recordingFetch{}
+	l := newLoader(ctx, f.fetch)
+
+	values, err := l.LoadMany(ctx, []int{1, 2, 1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{2, 4, 2, 4, 6}; !reflect.DeepEqual(values, want) {
+		t.Fatalf("want values %v, got %v", want, values)
+	}
+
+	// A key loaded earlier in the request is served from the finished batch.
+	value, err := l.Load(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 4 {
+		t.Fatalf("want 4, got %d", value)
+	}
+
+	want := [][]int{{1, 2, 3}}
+	if calls := f.sortedCalls(); !reflect.DeepEqual(calls, want) {
+		t.Fatalf("want one fetch %v, got %v", want, calls)
+	}
+}
+
+func TestLoaderSplitsAtMaxBatch(t *testing.T) {
+	ctx := context.Background()
+	f := &recordingFetch{}
+	l := newLoader(ctx, f.fetch)
+
+	keys := make([]int, maxBatch+1)
+	for i := range keys {
+		keys[i] = i
+	}
+	values, err := l.LoadMany(ctx, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, value := range values {
+		if value != i*2 {
+			t.Fatalf("key %d: want %d, got %d", i, i*2, value)
+		}
+	}
+
+	calls := f.sortedCalls()
+	sort.Slice(calls, func(i, j int) bool { return len(calls[i]) > len(calls[j]) })
+	if len(calls) != 2 || len(calls[0]) != maxBatch || len(calls[1]) != 1 {
+		sizes := make([]int, len(calls))
+		for i, keys := range calls {
+			sizes[i] = len(keys)
+		}
+		t.Fatalf("want fetches of %d and 1 keys, got sizes %v", maxBatch, sizes)
+	}
+	if !reflect.DeepEqual(calls[0], keys[:maxBatch]) || calls[1][0] != maxBatch {
+		t.Fatalf("want the first %d keys in the full batch and key %d in the next", maxBatch, maxBatch)
+	}
+}
+
+func TestLoaderErrorReachesEveryWaiter(t *testing.T) {
+	ctx := context.Background()
+	errFetch := errors.New("database is down")
+	l := newLoader(ctx, func(ctx context.Context, keys []int) (map[int]int, error) {
+		return nil, errFetch
+	})
+
+	const n = 5
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Two waiters per key: the second shares the first one's batch.
+			_, errs[i] = l.Load(ctx, i%2)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if !errors.Is(err, errFetch) {
+			t.Fatalf("load %d: want %v, got %v", i, errFetch, err)
+		}
+	}
+	if _, err := l.LoadMany(ctx, []int{0, 1}); !errors.Is(err, errFetch) {
+		t.Fatalf("LoadMany: want %v, got %v", errFetch, err)
+	}
+}
+
+func TestLoaderContextCancelledWhileWaiting(t *testing.T) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	l := newLoader(context.Background(), func(ctx context.Context, keys []int) (map[int]int, error) {
+		close(started)
+		<-unblock
+		return map[int]int{1: 2}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() {
+		_, err := l.Load(ctx, 1)
+		result <- err
+	}()
+
+	<-started
+	cancel()
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("want %v, got %v", context.Canceled, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Load did not return after its context was cancelled")
+	}
+
+	// The fetch itself is not cancelled: a waiter with a live context still gets the value.
+	close(unblock)
+	value, err := l.Load(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 2 {
+		t.Fatalf("want 2, got %d", value)
+	}
+}
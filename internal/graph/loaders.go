@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"context"
+
+	"pr-review-service/internal/database"
+	"pr-review-service/internal/models"
+)
+
+// statusKey identifies per-status lists such as a user's OPEN reviews; an empty Status means any.
+type statusKey struct {
+	ID     string
+	Status string
+}
+
+type loaders struct {
+	users        *loader[string, *models.User]
+	teams        *loader[string, *models.Team]
+	childTeams   *loader[string, []string]
+	pullRequests *loader[string, *models.PullRequest]
+	authoredPRs  *loader[statusKey, []*models.PullRequest]
+	userReviews  *loader[statusKey, []models.ReviewerAssignment]
+	prReviews    *loader[string, []models.ReviewerAssignment]
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, db *database.DB) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		users:        newLoader(ctx, logged("loading users", db.UsersByID)),
+		teams:        newLoader(ctx, logged("loading teams", db.TeamsByName)),
+		childTeams:   newLoader(ctx, logged("loading sub-teams", db.ChildTeamNames)),
+		pullRequests: newLoader(ctx, logged("loading pull requests", db.PullRequestsByID)),
+		authoredPRs:  newLoader(ctx, logged("loading authored pull requests", byStatus(db.PullRequestsByAuthor))),
+		userReviews:  newLoader(ctx, logged("loading user reviews", byStatus(db.ReviewsByReviewer))),
+		prReviews:    newLoader(ctx, logged("loading pull request reviews", db.ReviewsByPR)),
+	})
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// logged converts fetch errors with dbError, so each failed batch is logged once.
+func logged[K comparable, V any](action string, fetch func(context.Context, []K) (map[K]V, error)) func(context.Context, []K) (map[K]V, error) {
+	return func(ctx context.Context, keys []K) (map[K]V, error) {
+		values, err := fetch(ctx, keys)
+		if err != nil {
+			return nil, dbError(action, err)
+		}
+		return values, nil
+	}
+}
+
+// byStatus adapts a batch query taking one status to keys that may ask for different statuses.
+func byStatus[V any](fetch func(context.Context, []string, string) (map[string]V, error)) func(context.Context, []statusKey) (map[statusKey]V, error) {
+	return func(ctx context.Context, keys []statusKey) (map[statusKey]V, error) {
+		idsByStatus := make(map[string][]string)
+		for _, key := range keys {
+			idsByStatus[key.Status] = append(idsByStatus[key.Status], key.ID)
+		}
+
+		result := make(map[statusKey]V, len(keys))
+		for status, ids := range idsByStatus {
+			values, err := fetch(ctx, ids, status)
+			if err != nil {
+				return nil, err
+			}
+			for id, value := range values {
+				result[statusKey{ID: id, Status: status}] = value
+			}
+		}
+		return result, nil
+	}
+}
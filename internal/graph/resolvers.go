@@ -0,0 +1,293 @@
+package graph
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"pr-review-service/internal/database"
+	"pr-review-service/internal/models"
+	"pr-review-service/internal/validate"
+)
+
+type root struct {
+	db *database.DB
+}
+
+func (r *root) Team(ctx context.Context, args struct{ Name string }) (*teamResolver, error) {
+	return loadTeam(ctx, args.Name)
+}
+
+func (r *root) Teams(ctx context.Context, args struct{ Limit, Offset int32 }) ([]*teamResolver, error) {
+	if args.Limit < 1 || args.Limit > validate.MaxPageLimit || args.Offset < 0 {
+		return nil, &resolverError{code: models.ErrValidation, message: "limit must be between 1 and 200 and offset non-negative"}
+	}
+	names, _, err := r.db.ListTeamNames(ctx, int(args.Limit), int(args.Offset))
+	if err != nil {
+		return nil, dbError("listing teams", err)
+	}
+	return loadTeams(ctx, names)
+}
+
+func (r *root) User(ctx context.Context, args struct{ ID graphql.ID }) (*userResolver, error) {
+	return loadUser(ctx, string(args.ID))
+}
+
+func (r *root) PullRequest(ctx context.Context, args struct{ ID graphql.ID }) (*pullRequestResolver, error) {
+	return loadPullRequest(ctx, string(args.ID))
+}
+
+func loadTeam(ctx context.Context, name string) (*teamResolver, error) {
+	team, err := loadersFrom(ctx).teams.Load(ctx, name)
+	if err != nil || team == nil {
+		return nil, err
+	}
+	return &teamResolver{team}, nil
+}
+
+// loadTeams resolves team names in one batch, skipping teams deleted in the meantime.
+func loadTeams(ctx context.Context, names []string) ([]*teamResolver, error) {
+	teams, err := loadersFrom(ctx).teams.LoadMany(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*teamResolver, 0, len(teams))
+	for _, team := range teams {
+		if team != nil {
+			resolvers = append(resolvers, &teamResolver{team})
+		}
+	}
+	return resolvers, nil
+}
+
+func loadUser(ctx context.Context, userID string) (*userResolver, error) {
+	user, err := loadersFrom(ctx).users.Load(ctx, userID)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	return &userResolver{user}, nil
+}
+
+func loadPullRequest(ctx context.Context, prID string) (*pullRequestResolver, error) {
+	pr, err := loadersFrom(ctx).pullRequests.Load(ctx, prID)
+	if err != nil || pr == nil {
+		return nil, err
+	}
+	return &pullRequestResolver{pr}, nil
+}
+
+func optionalTime(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// Teams
+
+type teamResolver struct {
+	team *models.Team
+}
+
+func (r *teamResolver) Name() string {
+	return r.team.TeamName
+}
+
+func (r *teamResolver) ReviewSlaHours() *int32 {
+	if r.team.ReviewSLAHours == nil {
+		return nil
+	}
+	hours := int32(*r.team.ReviewSLAHours)
+	return &hours
+}
+
+func (r *teamResolver) Parent(ctx context.Context) (*teamResolver, error) {
+	if r.team.ParentTeamName == nil {
+		return nil, nil
+	}
+	return loadTeam(ctx, *r.team.ParentTeamName)
+}
+
+func (r *teamResolver) Children(ctx context.Context) ([]*teamResolver, error) {
+	names, err := loadersFrom(ctx).childTeams.Load(ctx, r.team.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	return loadTeams(ctx, names)
+}
+
+func (r *teamResolver) Members() []*teamMemberResolver {
+	members := make([]*teamMemberResolver, 0, len(r.team.Members))
+	for i := range r.team.Members {
+		members = append(members, &teamMemberResolver{&r.team.Members[i]})
+	}
+	return members
+}
+
+type teamMemberResolver struct {
+	member *models.TeamMember
+}
+
+func (r *teamMemberResolver) Role() string {
+	return strings.ToUpper(r.member.Role)
+}
+
+func (r *teamMemberResolver) User(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.member.UserID)
+}
+
+// Users
+
+type userResolver struct {
+	user *models.User
+}
+
+func (r *userResolver) ID() graphql.ID {
+	return graphql.ID(r.user.UserID)
+}
+
+func (r *userResolver) Username() string {
+	return r.user.Username
+}
+
+func (r *userResolver) IsActive() bool {
+	return r.user.IsActive
+}
+
+func (r *userResolver) DeletedAt() *graphql.Time {
+	return optionalTime(r.user.DeletedAt)
+}
+
+func (r *userResolver) PrimaryTeam(ctx context.Context) (*teamResolver, error) {
+	if r.user.TeamName == "" {
+		return nil, nil
+	}
+	return loadTeam(ctx, r.user.TeamName)
+}
+
+func (r *userResolver) Teams(ctx context.Context) ([]*teamResolver, error) {
+	return loadTeams(ctx, r.user.Teams)
+}
+
+type statusArgs struct {
+	Status *string
+}
+
+func (a statusArgs) key(id string) statusKey {
+	if a.Status == nil {
+		return statusKey{ID: id}
+	}
+	return statusKey{ID: id, Status: *a.Status}
+}
+
+func (r *userResolver) Reviews(ctx context.Context, args statusArgs) ([]*reviewResolver, error) {
+	reviews, err := loadersFrom(ctx).userReviews.Load(ctx, args.key(r.user.UserID))
+	if err != nil {
+		return nil, err
+	}
+	return reviewResolvers(reviews), nil
+}
+
+func (r *userResolver) PullRequests(ctx context.Context, args statusArgs) ([]*pullRequestResolver, error) {
+	prs, err := loadersFrom(ctx).authoredPRs.Load(ctx, args.key(r.user.UserID))
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*pullRequestResolver, 0, len(prs))
+	for _, pr := range prs {
+		resolvers = append(resolvers, &pullRequestResolver{pr})
+	}
+	return resolvers, nil
+}
+
+// Pull requests
+
+type pullRequestResolver struct {
+	pr *models.PullRequest
+}
+
+func (r *pullRequestResolver) ID() graphql.ID {
+	return graphql.ID(r.pr.PullRequestID)
+}
+
+func (r *pullRequestResolver) Name() string {
+	return r.pr.PullRequestName
+}
+
+func (r *pullRequestResolver) Status() string {
+	return r.pr.Status
+}
+
+func (r *pullRequestResolver) Priority() string {
+	return strings.ToUpper(r.pr.Priority)
+}
+
+func (r *pullRequestResolver) RepositoryName() *string {
+	return optionalString(r.pr.RepositoryName)
+}
+
+func (r *pullRequestResolver) CreatedAt() *graphql.Time {
+	return optionalTime(r.pr.CreatedAt)
+}
+
+func (r *pullRequestResolver) MergedAt() *graphql.Time {
+	return optionalTime(r.pr.MergedAt)
+}
+
+func (r *pullRequestResolver) Author(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.pr.AuthorID)
+}
+
+func (r *pullRequestResolver) Parent(ctx context.Context) (*pullRequestResolver, error) {
+	if r.pr.ParentPullRequestID == "" {
+		return nil, nil
+	}
+	return loadPullRequest(ctx, r.pr.ParentPullRequestID)
+}
+
+func (r *pullRequestResolver) Reviews(ctx context.Context) ([]*reviewResolver, error) {
+	reviews, err := loadersFrom(ctx).prReviews.Load(ctx, r.pr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return reviewResolvers(reviews), nil
+}
+
+// Reviews
+
+type reviewResolver struct {
+	review *models.ReviewerAssignment
+}
+
+func reviewResolvers(reviews []models.ReviewerAssignment) []*reviewResolver {
+	resolvers := make([]*reviewResolver, 0, len(reviews))
+	for i := range reviews {
+		resolvers = append(resolvers, &reviewResolver{&reviews[i]})
+	}
+	return resolvers
+}
+
+func (r *reviewResolver) PullRequest(ctx context.Context) (*pullRequestResolver, error) {
+	return loadPullRequest(ctx, r.review.PullRequestID)
+}
+
+func (r *reviewResolver) Reviewer(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.review.UserID)
+}
+
+func (r *reviewResolver) AssignedAt() graphql.Time {
+	return graphql.Time{Time: r.review.AssignedAt}
+}
+
+func (r *reviewResolver) RespondedAt() *graphql.Time {
+	return optionalTime(r.review.RespondedAt)
+}
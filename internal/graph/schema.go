@@ -0,0 +1,88 @@
+package graph
+
+// schema is the GraphQL SDL served at /graphql. Enum values are the upper-cased API values
+// (e.g. priority hotfix is HOTFIX).
+const schema = `
+schema {
+	query: Query
+}
+
+scalar Time
+
+type Query {
+	team(name: String!): Team
+	teams(limit: Int = 50, offset: Int = 0): [Team!]!
+	user(id: ID!): User
+	pullRequest(id: ID!): PullRequest
+}
+
+enum TeamRole {
+	LEAD
+	MEMBER
+	OBSERVER
+}
+
+enum PullRequestStatus {
+	OPEN
+	MERGED
+}
+
+enum Priority {
+	LOW
+	NORMAL
+	HIGH
+	HOTFIX
+}
+
+type Team {
+	name: String!
+	# Review SLA in hours; teams without an SLA have no overdue PRs.
+	reviewSlaHours: Int
+	parent: Team
+	children: [Team!]!
+	# Direct members; members of sub-teams are listed on the sub-teams.
+	members: [TeamMember!]!
+}
+
+type TeamMember {
+	role: TeamRole!
+	user: User!
+}
+
+type User {
+	id: ID!
+	username: String!
+	isActive: Boolean!
+	# Set for offboarded users, whose username is anonymised.
+	deletedAt: Time
+	# Reviewers for the user's PRs are assigned from this team.
+	primaryTeam: Team
+	teams: [Team!]!
+	# PRs the user is assigned to review, oldest assignment first.
+	reviews(status: PullRequestStatus): [Review!]!
+	# PRs the user authored, oldest first.
+	pullRequests(status: PullRequestStatus): [PullRequest!]!
+}
+
+type PullRequest {
+	id: ID!
+	name: String!
+	status: PullRequestStatus!
+	priority: Priority!
+	repositoryName: String
+	createdAt: Time
+	mergedAt: Time
+	author: User!
+	# Parent PR in a stack.
+	parent: PullRequest
+	reviews: [Review!]!
+}
+
+type Review {
+	pullRequest: PullRequest!
+	reviewer: User!
+	assignedAt: Time!
+	# First response of the reviewer, if any.
+	respondedAt: Time
+}
+`
@@ -0,0 +1,149 @@
+package grpcapi
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	prreviewv1 "pr-review-service/api/prreview/v1"
+	"pr-review-service/internal/models"
+)
+
+// Conversions between the models used by the database layer and the prreview.v1 messages.
+
+var (
+	roles = map[string]prreviewv1.TeamRole{
+		models.RoleLead:     prreviewv1.TeamRole_TEAM_ROLE_LEAD,
+		models.RoleMember:   prreviewv1.TeamRole_TEAM_ROLE_MEMBER,
+		models.RoleObserver: prreviewv1.TeamRole_TEAM_ROLE_OBSERVER,
+	}
+	statuses = map[string]prreviewv1.PullRequestStatus{
+		models.StatusOpen:   prreviewv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN,
+		models.StatusMerged: prreviewv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED,
+	}
+	priorities = map[string]prreviewv1.Priority{
+		models.PriorityLow:    prreviewv1.Priority_PRIORITY_LOW,
+		models.PriorityNormal: prreviewv1.Priority_PRIORITY_NORMAL,
+		models.PriorityHigh:   prreviewv1.Priority_PRIORITY_HIGH,
+		models.PriorityHotfix: prreviewv1.Priority_PRIORITY_HOTFIX,
+	}
+)
+
+// fromEnum finds the model value of an enum; UNSPECIFIED and unknown values yield "".
+func fromEnum[E comparable](values map[string]E, value E) string {
+	for model, enum := range values {
+		if enum == value {
+			return model
+		}
+	}
+	return ""
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func int32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	i := int32(*v)
+	return &i
+}
+
+func teamToProto(team *models.Team) *prreviewv1.Team {
+	members := make([]*prreviewv1.TeamMember, 0, len(team.Members))
+	for _, m := range team.Members {
+		members = append(members, &prreviewv1.TeamMember{
+			UserId:   m.UserID,
+			Username: m.Username,
+			IsActive: m.IsActive,
+			Role:     roles[m.Role],
+		})
+	}
+	return &prreviewv1.Team{
+		TeamName:       team.TeamName,
+		ParentTeamName: team.ParentTeamName,
+		ReviewSlaHours: int32Ptr(team.ReviewSLAHours),
+		Members:        members,
+	}
+}
+
+func membersFromProto(members []*prreviewv1.TeamMember) []models.TeamMember {
+	result := make([]models.TeamMember, 0, len(members))
+	for _, m := range members {
+		result = append(result, models.TeamMember{
+			UserID:   m.GetUserId(),
+			Username: m.GetUsername(),
+			IsActive: m.GetIsActive(),
+			Role:     fromEnum(roles, m.GetRole()),
+		})
+	}
+	return result
+}
+
+func userToProto(user *models.User) *prreviewv1.User {
+	return &prreviewv1.User{
+		UserId:    user.UserID,
+		Username:  user.Username,
+		TeamName:  user.TeamName,
+		IsActive:  user.IsActive,
+		Teams:     user.Teams,
+		DeletedAt: timestamp(user.DeletedAt),
+	}
+}
+
+func profileToProto(profile *models.UserProfile) *prreviewv1.UserProfile {
+	memberships := make([]*prreviewv1.TeamMembership, 0, len(profile.Memberships))
+	for _, m := range profile.Memberships {
+		memberships = append(memberships, &prreviewv1.TeamMembership{
+			TeamName:  m.TeamName,
+			Role:      roles[m.Role],
+			IsPrimary: m.IsPrimary,
+		})
+	}
+	return &prreviewv1.UserProfile{
+		User:           userToProto(&profile.User),
+		Memberships:    memberships,
+		Skills:         profile.Skills,
+		OpenReviews:    int32(profile.ReviewLoad.OpenReviews),
+		PendingReviews: int32(profile.ReviewLoad.PendingReviews),
+	}
+}
+
+func pullRequestToProto(pr *models.PullRequest) *prreviewv1.PullRequest {
+	return &prreviewv1.PullRequest{
+		PullRequestId:       pr.PullRequestID,
+		PullRequestName:     pr.PullRequestName,
+		AuthorId:            pr.AuthorID,
+		Status:              statuses[pr.Status],
+		Priority:            priorities[pr.Priority],
+		RepositoryName:      pr.RepositoryName,
+		ParentPullRequestId: pr.ParentPullRequestID,
+		AssignedReviewers:   pr.AssignedReviewers,
+		CreatedAt:           timestamp(pr.CreatedAt),
+		MergedAt:            timestamp(pr.MergedAt),
+		Version:             int32(pr.Version),
+	}
+}
+
+func shortToProto(pr *models.PullRequestShort) *prreviewv1.PullRequestShort {
+	return &prreviewv1.PullRequestShort{
+		PullRequestId:   pr.PullRequestID,
+		PullRequestName: pr.PullRequestName,
+		AuthorId:        pr.AuthorID,
+		Status:          statuses[pr.Status],
+	}
+}
+
+func assignmentToProto(a *models.ReviewerAssignment) *prreviewv1.ReviewerAssignment {
+	return &prreviewv1.ReviewerAssignment{
+		PullRequestId: a.PullRequestID,
+		UserId:        a.UserID,
+		AssignedAt:    timestamppb.New(a.AssignedAt),
+		RespondedAt:   timestamp(a.RespondedAt),
+	}
+}
@@ -0,0 +1,64 @@
+package grpcapi
+
+import (
+	"errors"
+	"log"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/database"
+	"pr-review-service/internal/models"
+)
+
+// errorDomain is the google.rpc.ErrorInfo domain; the reason is the HTTP API error code.
+const errorDomain = "pr-review-service"
+
+// errorCodes maps domain error codes to gRPC status codes, the counterpart of the HTTP
+// handlers' errorTypes table. Conflicts with existing rows are ALREADY_EXISTS, operations the
+// current state does not allow (merged PR, unmerged parent, ...) are FAILED_PRECONDITION, and a
+// stale expected_version is ABORTED: the caller should re-read the PR and retry.
+var errorCodes = map[string]codes.Code{
+	models.ErrNotFound:           codes.NotFound,
+	models.ErrTeamExists:         codes.AlreadyExists,
+	models.ErrPRExists:           codes.AlreadyExists,
+	models.ErrRepositoryExists:   codes.AlreadyExists,
+	models.ErrUserExists:         codes.AlreadyExists,
+	models.ErrPRMerged:           codes.FailedPrecondition,
+	models.ErrNotAssigned:        codes.FailedPrecondition,
+	models.ErrNoCandidate:        codes.FailedPrecondition,
+	models.ErrParentNotMerged:    codes.FailedPrecondition,
+	models.ErrTeamHasOpenReviews: codes.FailedPrecondition,
+	models.ErrTeamHasRepos:       codes.FailedPrecondition,
+	models.ErrTeamCycle:          codes.FailedPrecondition,
+	models.ErrUserDeleted:        codes.FailedPrecondition,
+	models.ErrPreconditionFailed: codes.Aborted,
+}
+
+// dbError converts a database error to a gRPC status. Domain errors keep their code and
+// entity fields in an ErrorInfo detail; connection problems are UNAVAILABLE so that clients
+// retry, anything else is INTERNAL. method names the failed RPC for the log.
+func dbError(method string, err error) error {
+	var appErr *apperr.Error
+	if errors.As(err, &appErr) {
+		if code, ok := errorCodes[appErr.Code]; ok {
+			st, detailErr := status.New(code, appErr.Message).WithDetails(&errdetails.ErrorInfo{
+				Reason:   appErr.Code,
+				Domain:   errorDomain,
+				Metadata: appErr.Fields,
+			})
+			if detailErr != nil {
+				return status.Error(code, appErr.Message)
+			}
+			return st.Err()
+		}
+	}
+
+	log.Printf("gRPC %s failed: %v", method, err)
+	if database.IsUnavailable(err) {
+		return status.Error(codes.Unavailable, "database is temporarily unavailable")
+	}
+	return status.Error(codes.Internal, "internal server error")
+}
@@ -0,0 +1,80 @@
+// Package grpcapi serves the prreview.v1 gRPC services (api/prreview/v1) for internal callers.
+// It calls the same database.DB methods as the HTTP handlers, so both APIs share behaviour;
+// domain errors are translated to gRPC status codes in errors.go.
+package grpcapi
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	prreviewv1 "pr-review-service/api/prreview/v1"
+	"pr-review-service/internal/database"
+	"pr-review-service/internal/validate"
+)
+
+type Server struct {
+	prreviewv1.UnimplementedTeamServiceServer
+	prreviewv1.UnimplementedUserServiceServer
+	prreviewv1.UnimplementedPullRequestServiceServer
+
+	db *database.DB
+}
+
+func New(db *database.DB) *Server {
+	return &Server{db: db}
+}
+
+// NewGRPCServer returns a grpc.Server with all prreview.v1 services registered.
+func NewGRPCServer(db *database.DB) *grpc.Server {
+	s := New(db)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(logUnary))
+	prreviewv1.RegisterTeamServiceServer(srv, s)
+	prreviewv1.RegisterUserServiceServer(srv, s)
+	prreviewv1.RegisterPullRequestServiceServer(srv, s)
+	return srv
+}
+
+func logUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	log.Printf("gRPC %s %s %s", info.FullMethod, status.Code(err), time.Since(start))
+	return resp, err
+}
+
+// validator collects field violations with the same rules as the HTTP handlers; err renders
+// them as google.rpc.BadRequest.
+type validator struct {
+	validate.Validator
+}
+
+// page applies the default page size (a zero limit) and reports out-of-range values.
+func (v *validator) page(limit, offset int32) (int, int) {
+	if limit == 0 {
+		limit = validate.DefaultPageLimit
+	}
+	v.Page(int(limit), int(offset))
+	return int(limit), int(offset)
+}
+
+// err returns INVALID_ARGUMENT with a google.rpc.BadRequest detail, or nil if every field is valid.
+func (v *validator) err() error {
+	if v.Valid() {
+		return nil
+	}
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(v.Fields))
+	for _, field := range v.Fields {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field.Field, Description: field.Message})
+	}
+	st, detailErr := status.New(codes.InvalidArgument, "request validation failed").
+		WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailErr != nil {
+		return status.Error(codes.InvalidArgument, "request validation failed")
+	}
+	return st.Err()
+}
@@ -0,0 +1,147 @@
+package grpcapi
+
+import (
+	"context"
+
+	prreviewv1 "pr-review-service/api/prreview/v1"
+	"pr-review-service/internal/models"
+	"pr-review-service/internal/validate"
+)
+
+func (s *Server) CreatePullRequest(ctx context.Context, req *prreviewv1.CreatePullRequestRequest) (*prreviewv1.PullRequest, error) {
+	priority := models.PriorityNormal
+	if req.GetPriority() != prreviewv1.Priority_PRIORITY_UNSPECIFIED {
+		priority = fromEnum(priorities, req.GetPriority())
+	}
+	var v validator
+	v.Required("pull_request_id", req.GetPullRequestId(), validate.MaxIDLength)
+	v.Required("pull_request_name", req.GetPullRequestName(), validate.MaxPRNameLength)
+	v.Required("author_id", req.GetAuthorId(), validate.MaxIDLength)
+	v.Check(priority != "", "priority", "unknown priority")
+	v.MaxLength("repository_name", req.GetRepositoryName(), validate.MaxNameLength)
+	v.MaxLength("parent_pull_request_id", req.GetParentPullRequestId(), validate.MaxIDLength)
+	v.Check(req.GetParentPullRequestId() == "" || req.GetParentPullRequestId() != req.GetPullRequestId(), "parent_pull_request_id", "must differ from pull_request_id")
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.db.CreatePR(ctx, &models.PullRequest{
+		PullRequestID:       req.GetPullRequestId(),
+		PullRequestName:     req.GetPullRequestName(),
+		AuthorID:            req.GetAuthorId(),
+		Priority:            priority,
+		RepositoryName:      req.GetRepositoryName(),
+		ParentPullRequestID: req.GetParentPullRequestId(),
+	}, req.InheritReviewers == nil || req.GetInheritReviewers())
+	if err != nil {
+		return nil, dbError("CreatePullRequest", err)
+	}
+	return pullRequestToProto(pr), nil
+}
+
+func (s *Server) GetPullRequest(ctx context.Context, req *prreviewv1.GetPullRequestRequest) (*prreviewv1.GetPullRequestResponse, error) {
+	var v validator
+	v.Required("pull_request_id", req.GetPullRequestId(), validate.MaxIDLength)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.db.GetPR(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, dbError("GetPullRequest", err)
+	}
+	stack, err := s.db.GetPRStack(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, dbError("GetPullRequest", err)
+	}
+
+	resp := &prreviewv1.GetPullRequestResponse{
+		PullRequest: pullRequestToProto(pr),
+		Stack:       make([]*prreviewv1.StackEntry, 0, len(stack)),
+	}
+	for i := range stack {
+		resp.Stack = append(resp.Stack, &prreviewv1.StackEntry{
+			PullRequest:         shortToProto(&stack[i].PullRequestShort),
+			ParentPullRequestId: stack[i].ParentPullRequestID,
+			Depth:               int32(stack[i].Depth),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) MergePullRequest(ctx context.Context, req *prreviewv1.MergePullRequestRequest) (*prreviewv1.PullRequest, error) {
+	var v validator
+	v.Required("pull_request_id", req.GetPullRequestId(), validate.MaxIDLength)
+	v.Check(req.GetExpectedVersion() >= 0, "expected_version", "must not be negative")
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.db.MergePR(ctx, req.GetPullRequestId(), int(req.GetExpectedVersion()))
+	if err != nil {
+		return nil, dbError("MergePullRequest", err)
+	}
+	return pullRequestToProto(pr), nil
+}
+
+func (s *Server) ReassignReviewer(ctx context.Context, req *prreviewv1.ReassignReviewerRequest) (*prreviewv1.ReassignReviewerResponse, error) {
+	var v validator
+	v.Required("pull_request_id", req.GetPullRequestId(), validate.MaxIDLength)
+	v.Required("old_user_id", req.GetOldUserId(), validate.MaxIDLength)
+	v.Check(req.GetExpectedVersion() >= 0, "expected_version", "must not be negative")
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	pr, replacedBy, err := s.db.ReassignReviewer(ctx, req.GetPullRequestId(), req.GetOldUserId(), int(req.GetExpectedVersion()))
+	if err != nil {
+		return nil, dbError("ReassignReviewer", err)
+	}
+	return &prreviewv1.ReassignReviewerResponse{PullRequest: pullRequestToProto(pr), ReplacedBy: replacedBy}, nil
+}
+
+func (s *Server) RespondToReview(ctx context.Context, req *prreviewv1.RespondToReviewRequest) (*prreviewv1.ReviewerAssignment, error) {
+	var v validator
+	v.Required("pull_request_id", req.GetPullRequestId(), validate.MaxIDLength)
+	v.Required("user_id", req.GetUserId(), validate.MaxIDLength)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	assignment, err := s.db.RespondToReview(ctx, req.GetPullRequestId(), req.GetUserId())
+	if err != nil {
+		return nil, dbError("RespondToReview", err)
+	}
+	return assignmentToProto(assignment), nil
+}
+
+func (s *Server) ListOverduePullRequests(ctx context.Context, req *prreviewv1.ListOverduePullRequestsRequest) (*prreviewv1.ListOverduePullRequestsResponse, error) {
+	var v validator
+	v.MaxLength("team_name", req.GetTeamName(), validate.MaxNameLength)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	prs, err := s.db.GetOverduePRs(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, dbError("ListOverduePullRequests", err)
+	}
+	resp := &prreviewv1.ListOverduePullRequestsResponse{PullRequests: make([]*prreviewv1.OverduePullRequest, 0, len(prs))}
+	for i := range prs {
+		reviewers := make([]*prreviewv1.OverdueReviewer, 0, len(prs[i].OverdueReviewers))
+		for _, r := range prs[i].OverdueReviewers {
+			reviewers = append(reviewers, &prreviewv1.OverdueReviewer{
+				UserId:         r.UserID,
+				AssignedAt:     timestamp(&r.AssignedAt),
+				WaitingSeconds: r.WaitingSeconds,
+			})
+		}
+		resp.PullRequests = append(resp.PullRequests, &prreviewv1.OverduePullRequest{
+			PullRequest:      shortToProto(&prs[i].PullRequestShort),
+			TeamName:         prs[i].TeamName,
+			ReviewSlaHours:   int32(prs[i].ReviewSLAHours),
+			OverdueReviewers: reviewers,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,150 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+
+	prreviewv1 "pr-review-service/api/prreview/v1"
+	"pr-review-service/internal/models"
+	"pr-review-service/internal/validate"
+)
+
+// members validates team members as CreateTeam and AddTeamMembers accept them.
+func (v *validator) members(members []*prreviewv1.TeamMember) {
+	userIDs := make([]string, 0, len(members))
+	for i, m := range members {
+		prefix := fmt.Sprintf("members[%d].", i)
+		v.Required(prefix+"user_id", m.GetUserId(), validate.MaxIDLength)
+		v.Required(prefix+"username", m.GetUsername(), validate.MaxNameLength)
+		// An unspecified role keeps the current role or defaults to member.
+		v.Check(m.GetRole() == prreviewv1.TeamRole_TEAM_ROLE_UNSPECIFIED || fromEnum(roles, m.GetRole()) != "", prefix+"role", "unknown role")
+		userIDs = append(userIDs, m.GetUserId())
+	}
+	v.Unique("members", ".user_id", userIDs)
+}
+
+func (s *Server) CreateTeam(ctx context.Context, req *prreviewv1.CreateTeamRequest) (*prreviewv1.Team, error) {
+	in := req.GetTeam()
+	var v validator
+	v.Required("team.team_name", in.GetTeamName(), validate.MaxNameLength)
+	if in.ParentTeamName != nil {
+		v.Required("team.parent_team_name", in.GetParentTeamName(), validate.MaxNameLength)
+	}
+	v.Check(in.ReviewSlaHours == nil || in.GetReviewSlaHours() > 0, "team.review_sla_hours", "must be positive")
+	v.members(in.GetMembers())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	team := &models.Team{
+		TeamName:       in.GetTeamName(),
+		ParentTeamName: in.ParentTeamName,
+		Members:        membersFromProto(in.GetMembers()),
+	}
+	if in.ReviewSlaHours != nil {
+		hours := int(in.GetReviewSlaHours())
+		team.ReviewSLAHours = &hours
+	}
+	if err := s.db.CreateTeam(ctx, team); err != nil {
+		return nil, dbError("CreateTeam", err)
+	}
+	return teamToProto(team), nil
+}
+
+func (s *Server) GetTeam(ctx context.Context, req *prreviewv1.GetTeamRequest) (*prreviewv1.Team, error) {
+	var v validator
+	v.Required("team_name", req.GetTeamName(), validate.MaxNameLength)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	team, err := s.db.GetTeam(ctx, req.GetTeamName(), req.GetIncludeSubteams())
+	if err != nil {
+		return nil, dbError("GetTeam", err)
+	}
+	return teamToProto(team), nil
+}
+
+func (s *Server) ListTeams(ctx context.Context, req *prreviewv1.ListTeamsRequest) (*prreviewv1.ListTeamsResponse, error) {
+	var v validator
+	limit, offset := v.page(req.GetLimit(), req.GetOffset())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	teamNames, total, err := s.db.ListTeamNames(ctx, limit, offset)
+	if err != nil {
+		return nil, dbError("ListTeams", err)
+	}
+	return &prreviewv1.ListTeamsResponse{TeamNames: teamNames, Total: int32(total)}, nil
+}
+
+func (s *Server) AddTeamMembers(ctx context.Context, req *prreviewv1.AddTeamMembersRequest) (*prreviewv1.Team, error) {
+	var v validator
+	v.Required("team_name", req.GetTeamName(), validate.MaxNameLength)
+	v.Check(len(req.GetMembers()) > 0, "members", "must not be empty")
+	v.members(req.GetMembers())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	team, err := s.db.AddTeamMembers(ctx, req.GetTeamName(), membersFromProto(req.GetMembers()))
+	if err != nil {
+		return nil, dbError("AddTeamMembers", err)
+	}
+	return teamToProto(team), nil
+}
+
+func (s *Server) RemoveTeamMember(ctx context.Context, req *prreviewv1.RemoveTeamMemberRequest) (*prreviewv1.Team, error) {
+	var v validator
+	v.Required("team_name", req.GetTeamName(), validate.MaxNameLength)
+	v.Required("user_id", req.GetUserId(), validate.MaxIDLength)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	team, err := s.db.RemoveTeamMember(ctx, req.GetTeamName(), req.GetUserId())
+	if err != nil {
+		return nil, dbError("RemoveTeamMember", err)
+	}
+	return teamToProto(team), nil
+}
+
+func (s *Server) SetMemberRole(ctx context.Context, req *prreviewv1.SetMemberRoleRequest) (*prreviewv1.Team, error) {
+	role := fromEnum(roles, req.GetRole())
+	var v validator
+	v.Required("team_name", req.GetTeamName(), validate.MaxNameLength)
+	v.Required("user_id", req.GetUserId(), validate.MaxIDLength)
+	v.Check(role != "", "role", "is required")
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	team, err := s.db.SetMemberRole(ctx, req.GetTeamName(), req.GetUserId(), role)
+	if err != nil {
+		return nil, dbError("SetMemberRole", err)
+	}
+	return teamToProto(team), nil
+}
+
+func (s *Server) GetTeamStats(ctx context.Context, req *prreviewv1.GetTeamStatsRequest) (*prreviewv1.TeamStats, error) {
+	var v validator
+	v.Required("team_name", req.GetTeamName(), validate.MaxNameLength)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.db.GetTeamStats(ctx, req.GetTeamName(), req.GetIncludeSubteams())
+	if err != nil {
+		return nil, dbError("GetTeamStats", err)
+	}
+	return &prreviewv1.TeamStats{
+		TeamName:          stats.TeamName,
+		IncludeSubteams:   stats.IncludeSubteams,
+		TeamCount:         int32(stats.TeamCount),
+		MemberCount:       int32(stats.MemberCount),
+		ActiveMemberCount: int32(stats.ActiveMemberCount),
+		OpenPullRequests:  int32(stats.OpenPullRequests),
+		PendingReviews:    int32(stats.PendingReviews),
+	}, nil
+}
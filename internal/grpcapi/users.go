@@ -0,0 +1,133 @@
+package grpcapi
+
+import (
+	"context"
+
+	prreviewv1 "pr-review-service/api/prreview/v1"
+	"pr-review-service/internal/models"
+	"pr-review-service/internal/validate"
+)
+
+func (s *Server) GetUser(ctx context.Context, req *prreviewv1.GetUserRequest) (*prreviewv1.UserProfile, error) {
+	var v validator
+	v.Required("user_id", req.GetUserId(), validate.MaxIDLength)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	profile, err := s.db.GetUserProfile(ctx, req.GetUserId())
+	if err != nil {
+		return nil, dbError("GetUser", err)
+	}
+	return profileToProto(profile), nil
+}
+
+func (s *Server) ListUsers(ctx context.Context, req *prreviewv1.ListUsersRequest) (*prreviewv1.ListUsersResponse, error) {
+	filter := models.UserFilter{
+		TeamName:        req.GetTeamName(),
+		IncludeSubteams: req.GetIncludeSubteams(),
+		IsActive:        req.IsActive,
+		Skill:           req.GetSkill(),
+		Search:          req.GetSearch(),
+	}
+	var v validator
+	filter.Limit, filter.Offset = v.page(req.GetLimit(), req.GetOffset())
+	v.MaxLength("team_name", filter.TeamName, validate.MaxNameLength)
+	v.MaxLength("skill", filter.Skill, validate.MaxSkillLength)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	users, total, err := s.db.ListUsers(ctx, filter)
+	if err != nil {
+		return nil, dbError("ListUsers", err)
+	}
+	resp := &prreviewv1.ListUsersResponse{Users: make([]*prreviewv1.User, 0, len(users)), Total: int32(total)}
+	for i := range users {
+		resp.Users = append(resp.Users, userToProto(&users[i]))
+	}
+	return resp, nil
+}
+
+func (s *Server) SetUserActive(ctx context.Context, req *prreviewv1.SetUserActiveRequest) (*prreviewv1.User, error) {
+	var v validator
+	v.Required("user_id", req.GetUserId(), validate.MaxIDLength)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.db.SetUserActive(ctx, req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		return nil, dbError("SetUserActive", err)
+	}
+	return userToProto(user), nil
+}
+
+func (s *Server) MoveUser(ctx context.Context, req *prreviewv1.MoveUserRequest) (*prreviewv1.User, error) {
+	var v validator
+	v.Required("user_id", req.GetUserId(), validate.MaxIDLength)
+	v.MaxLength("from_team_name", req.GetFromTeamName(), validate.MaxNameLength)
+	v.Required("team_name", req.GetTeamName(), validate.MaxNameLength)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.db.MoveUserToTeam(ctx, req.GetUserId(), req.GetFromTeamName(), req.GetTeamName())
+	if err != nil {
+		return nil, dbError("MoveUser", err)
+	}
+	return userToProto(user), nil
+}
+
+func (s *Server) GetUserReviews(ctx context.Context, req *prreviewv1.GetUserReviewsRequest) (*prreviewv1.GetUserReviewsResponse, error) {
+	status := fromEnum(statuses, req.GetStatus())
+	var v validator
+	v.Required("user_id", req.GetUserId(), validate.MaxIDLength)
+	v.Check(req.GetStatus() == prreviewv1.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED || status != "", "status", "unknown status")
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.db.GetUserReviews(ctx, req.GetUserId(), status)
+	if err != nil {
+		return nil, dbError("GetUserReviews", err)
+	}
+	resp := &prreviewv1.GetUserReviewsResponse{Reviews: make([]*prreviewv1.UserReview, 0, len(reviews))}
+	for i := range reviews {
+		r := &reviews[i]
+		resp.Reviews = append(resp.Reviews, &prreviewv1.UserReview{
+			PullRequest:    shortToProto(&r.PullRequestShort),
+			Priority:       priorities[r.Priority],
+			UrgencyScore:   r.UrgencyScore,
+			AssignedAt:     timestamp(&r.AssignedAt),
+			RespondedAt:    timestamp(r.RespondedAt),
+			WaitingSeconds: r.WaitingSeconds,
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) OffboardUser(ctx context.Context, req *prreviewv1.OffboardUserRequest) (*prreviewv1.OffboardUserResponse, error) {
+	var v validator
+	v.Required("user_id", req.GetUserId(), validate.MaxIDLength)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.OffboardUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, dbError("OffboardUser", err)
+	}
+	resp := &prreviewv1.OffboardUserResponse{
+		User:       userToProto(&result.User),
+		Reassigned: make([]*prreviewv1.ReviewerReplacement, 0, len(result.Reassigned)),
+		Unassigned: result.Unassigned,
+	}
+	for _, r := range result.Reassigned {
+		resp.Reassigned = append(resp.Reassigned, &prreviewv1.ReviewerReplacement{
+			PullRequestId: r.PullRequestID,
+			ReplacedBy:    r.ReplacedBy,
+		})
+	}
+	return resp, nil
+}
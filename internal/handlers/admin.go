@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"pr-review-service/internal/models"
+	"pr-review-service/internal/orgimport"
+)
+
+const maxImportBytes = 10 << 20
+
+// ImportOrgChart bulk-imports teams and users from a CSV or YAML body. The format comes from the
+// format query parameter or the Content-Type; dry_run=true only returns the diff.
+func (h *Handler) ImportOrgChart(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = orgimport.FormatFromContentType(r.Header.Get("Content-Type"))
+	}
+	var v validator
+	v.Check(format == orgimport.FormatCSV || format == orgimport.FormatYAML, "format", "must be csv or yaml")
+	if !h.valid(w, r, &v) {
+		return
+	}
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+
+	chart, err := orgimport.Parse(http.MaxBytesReader(w, r.Body, maxImportBytes), format)
+	if err != nil {
+		h.respondImportError(w, r, err)
+		return
+	}
+
+	diff, err := orgimport.Import(r.Context(), h.db, chart, dryRun)
+	if err != nil {
+		h.respondImportError(w, r, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"diff":    diff,
+		"applied": !dryRun,
+	})
+}
+
+func (h *Handler) respondImportError(w http.ResponseWriter, r *http.Request, err error) {
+	var validationErr *orgimport.ValidationError
+	if errors.As(err, &validationErr) {
+		h.writeError(w, r, http.StatusBadRequest, models.ErrorDetail{
+			Code:    models.ErrInvalidImport,
+			Message: "org chart is invalid",
+			Details: validationErr.Problems,
+		}, nil)
+		return
+	}
+	h.respondDBError(w, r, "importing org chart", err)
+}
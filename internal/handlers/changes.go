@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"pr-review-service/internal/models"
+)
+
+const (
+	defaultChangesLimit = 500
+	maxChangesLimit     = 5000
+)
+
+// GetChanges serves the change feed for incremental sync: changes after the since cursor, in
+// the order they were committed. A client stores next_cursor and asks again with it, right
+// away while has_more is set. A cursor older than the retention answers 410 CURSOR_EXPIRED.
+func (h *Handler) GetChanges(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var v validator
+	var since int64
+	if value := query.Get("since"); value != "" {
+		var err error
+		since, err = strconv.ParseInt(value, 10, 64)
+		v.Check(err == nil && since >= 0, "since", "must be a cursor returned by this endpoint")
+	}
+	limit := defaultChangesLimit
+	if value := query.Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		v.Check(err == nil && limit >= 1 && limit <= maxChangesLimit, "limit", "must be between 1 and 5000")
+	}
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	changes, err := h.db.ChangesSince(r.Context(), since, limit+1)
+	if err != nil {
+		h.respondDBError(w, r, "loading changes", err)
+		return
+	}
+
+	page := models.ChangesPage{Changes: changes, NextCursor: since}
+	if len(changes) > limit {
+		page.Changes, page.HasMore = changes[:limit], true
+	}
+	if n := len(page.Changes); n > 0 {
+		page.NextCursor = page.Changes[n-1].Cursor
+	}
+	h.respondJSON(w, http.StatusOK, page)
+}
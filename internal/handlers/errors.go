@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/database"
+	"pr-review-service/internal/models"
+)
+
+type errorType struct {
+	status int
+	title  string
+}
+
+// errorTypes maps every error code the API returns to its HTTP status and a short, stable
+// title used in problem details. Domain errors from the database layer are looked up here.
+var errorTypes = map[string]errorType{
+	models.ErrInvalidRequest:     {http.StatusBadRequest, "Malformed request body"},
+	models.ErrValidation:         {http.StatusBadRequest, "Request validation failed"},
+	models.ErrInvalidImport:      {http.StatusBadRequest, "Invalid org chart"},
+	models.ErrNotFound:           {http.StatusNotFound, "Resource not found"},
+	models.ErrTeamExists:         {http.StatusBadRequest, "Team already exists"},
+	models.ErrPRExists:           {http.StatusConflict, "Pull request already exists"},
+	models.ErrPRMerged:           {http.StatusConflict, "Pull request is merged"},
+	models.ErrNotAssigned:        {http.StatusConflict, "Reviewer is not assigned"},
+	models.ErrNoCandidate:        {http.StatusConflict, "No replacement reviewer available"},
+	models.ErrRepositoryExists:   {http.StatusConflict, "Repository already exists"},
+	models.ErrParentNotMerged:    {http.StatusConflict, "Parent pull request is not merged"},
+	models.ErrTeamHasOpenReviews: {http.StatusConflict, "Team members have open reviews"},
+	models.ErrTeamHasRepos:       {http.StatusConflict, "Team owns repositories"},
+	models.ErrTeamCycle:          {http.StatusConflict, "Team hierarchy cycle"},
+	models.ErrUserExists:         {http.StatusConflict, "User already exists"},
+	models.ErrUserDeleted:        {http.StatusConflict, "User is offboarded"},
+	models.ErrIdempotencyReused:  {http.StatusUnprocessableEntity, "Idempotency key reused"},
+	models.ErrIdempotencyInUse:   {http.StatusConflict, "Idempotency key in use"},
+	models.ErrPreconditionFailed: {http.StatusPreconditionFailed, "Pull request was modified"},
+	models.ErrCursorExpired:      {http.StatusGone, "Change cursor expired"},
+	models.ErrInternal:           {http.StatusInternalServerError, "Internal server error"},
+	models.ErrUnavailable:        {http.StatusServiceUnavailable, "Service unavailable"},
+}
+
+// domainError returns the status and body of a domain error, e.g. for one item of a batch;
+// ok is false for any other error.
+func domainError(err error) (int, models.ErrorDetail, bool) {
+	var appErr *apperr.Error
+	if !errors.As(err, &appErr) {
+		return 0, models.ErrorDetail{}, false
+	}
+	kind, ok := errorTypes[appErr.Code]
+	return kind.status, models.ErrorDetail{Code: appErr.Code, Message: appErr.Message}, ok
+}
+
+// itemError answers a failed database call for one item of a batch with the status and body the
+// item would have got on its own; failures that are not domain errors are logged like in respondDBError.
+func itemError(r *http.Request, action string, err error) (int, models.ErrorDetail) {
+	if status, detail, ok := domainError(err); ok {
+		return status, detail
+	}
+
+	log.Printf("Error %s (request %s): %v", action, RequestID(r.Context()), err)
+	if database.IsUnavailable(err) {
+		return http.StatusServiceUnavailable, models.ErrorDetail{Code: models.ErrUnavailable, Message: "Database is temporarily unavailable"}
+	}
+	return http.StatusInternalServerError, models.ErrorDetail{Code: models.ErrInternal, Message: "Internal server error"}
+}
+
+// unavailableRetryAfter is the Retry-After hint, in seconds, sent while Postgres is unreachable.
+const unavailableRetryAfter = "5"
+
+// respondDBError answers a failed database call: domain errors with their mapped status and
+// code, connection problems with 503 and anything else with 500. action describes the failed
+// operation for the log, e.g. "creating team".
+func (h *Handler) respondDBError(w http.ResponseWriter, r *http.Request, action string, err error) {
+	var appErr *apperr.Error
+	if errors.As(err, &appErr) {
+		if kind, ok := errorTypes[appErr.Code]; ok {
+			h.writeError(w, r, kind.status, models.ErrorDetail{Code: appErr.Code, Message: appErr.Message}, appErr.Fields)
+			return
+		}
+	}
+
+	log.Printf("Error %s (request %s): %v", action, RequestID(r.Context()), err)
+	if database.IsUnavailable(err) {
+		w.Header().Set("Retry-After", unavailableRetryAfter)
+		h.respondError(w, r, http.StatusServiceUnavailable, models.ErrUnavailable, "Database is temporarily unavailable")
+		return
+	}
+	h.respondError(w, r, http.StatusInternalServerError, models.ErrInternal, "Internal server error")
+}
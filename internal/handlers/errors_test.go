@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/models"
+)
+
+// wantStatuses is the HTTP status clients rely on for every error code.
+var wantStatuses = map[string]int{
+	models.ErrInvalidRequest:     http.StatusBadRequest,
+	models.ErrValidation:         http.StatusBadRequest,
+	models.ErrInvalidImport:      http.StatusBadRequest,
+	models.ErrNotFound:           http.StatusNotFound,
+	models.ErrTeamExists:         http.StatusBadRequest,
+	models.ErrPRExists:           http.StatusConflict,
+	models.ErrPRMerged:           http.StatusConflict,
+	models.ErrNotAssigned:        http.StatusConflict,
+	models.ErrNoCandidate:        http.StatusConflict,
+	models.ErrRepositoryExists:   http.StatusConflict,
+	models.ErrParentNotMerged:    http.StatusConflict,
+	models.ErrTeamHasOpenReviews: http.StatusConflict,
+	models.ErrTeamHasRepos:       http.StatusConflict,
+	models.ErrTeamCycle:          http.StatusConflict,
+	models.ErrUserExists:         http.StatusConflict,
+	models.ErrUserDeleted:        http.StatusConflict,
+	models.ErrIdempotencyReused:  http.StatusUnprocessableEntity,
+	models.ErrIdempotencyInUse:   http.StatusConflict,
+	models.ErrPreconditionFailed: http.StatusPreconditionFailed,
+	models.ErrCursorExpired:      http.StatusGone,
+	models.ErrInternal:           http.StatusInternalServerError,
+	models.ErrUnavailable:        http.StatusServiceUnavailable,
+}
+
+func TestErrorTypesCoverEveryCode(t *testing.T) {
+	for code := range errorTypes {
+		if _, ok := wantStatuses[code]; !ok {
+			t.Errorf("error code %s has no expected status in this test", code)
+		}
+	}
+	for code := range wantStatuses {
+		if _, ok := errorTypes[code]; !ok {
+			t.Errorf("error code %s is missing from errorTypes", code)
+		}
+	}
+}
+
+func TestRespondDBErrorMapsDomainErrors(t *testing.T) {
+	for code, status := range wantStatuses {
+		t.Run(code, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			err := fmt.Errorf("wrapped: %w", &apperr.Error{Code: code, Message: "details"})
+
+			(&Handler{}).respondDBError(w, r, "testing", err)
+
+			if w.Code != status {
+				t.Fatalf("want status %d, got %d", status, w.Code)
+			}
+			var resp models.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Error.Code != code || resp.Error.Message != "details" {
+				t.Fatalf("want %s: details, got %s: %s", code, resp.Error.Code, resp.Error.Message)
+			}
+		})
+	}
+}
+
+func TestRespondDBErrorInfrastructure(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		status     int
+		code       string
+		retryAfter string
+	}{
+		{name: "unknown code", err: &apperr.Error{Code: "SOMETHING_NEW"}, status: http.StatusInternalServerError, code: models.ErrInternal},
+		{name: "plain error", err: errors.New("boom"), status: http.StatusInternalServerError, code: models.ErrInternal},
+		{name: "lost connection", err: driver.ErrBadConn, status: http.StatusServiceUnavailable, code: models.ErrUnavailable, retryAfter: unavailableRetryAfter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+
+			(&Handler{}).respondDBError(w, r, "testing", tt.err)
+
+			if w.Code != tt.status {
+				t.Fatalf("want status %d, got %d", tt.status, w.Code)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.retryAfter {
+				t.Fatalf("want Retry-After %q, got %q", tt.retryAfter, got)
+			}
+			var resp models.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Error.Code != tt.code {
+				t.Fatalf("want code %s, got %s", tt.code, resp.Error.Code)
+			}
+		})
+	}
+}
+
+func TestItemError(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "domain error", err: apperr.ErrPRExists, status: http.StatusConflict, code: models.ErrPRExists},
+		{name: "plain error", err: errors.New("boom"), status: http.StatusInternalServerError, code: models.ErrInternal},
+		{name: "lost connection", err: driver.ErrBadConn, status: http.StatusServiceUnavailable, code: models.ErrUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, detail := itemError(r, "testing", tt.err)
+			if status != tt.status || detail.Code != tt.code {
+				t.Fatalf("want %d %s, got %d %s", tt.status, tt.code, status, detail.Code)
+			}
+		})
+	}
+}
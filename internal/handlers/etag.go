@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"pr-review-service/internal/models"
+)
+
+// setETag serves the PR version as a strong ETag. Mutating PR endpoints accept it back in
+// If-Match and answer 412 PRECONDITION_FAILED once someone else has changed the PR.
+func setETag(w http.ResponseWriter, pr *models.PullRequest) {
+	w.Header().Set("ETag", `"`+strconv.Itoa(pr.Version)+`"`)
+}
+
+// ifMatch reads the PR version a mutation is conditional on; 0 means unconditional (no If-Match
+// or "*"). Only a single ETag as served by setETag is accepted.
+func (v *validator) ifMatch(r *http.Request) int {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0
+	}
+	version, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`))
+	if err != nil || version < 1 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		v.Add("If-Match", "must be a single ETag of the pull request")
+		return 0
+	}
+	return version
+}
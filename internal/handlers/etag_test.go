@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/models"
+)
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		version int
+		invalid bool
+	}{
+		{name: "absent", header: "", version: 0},
+		{name: "any", header: "*", version: 0},
+		{name: "strong tag", header: `"3"`, version: 3},
+		{name: "surrounding spaces", header: ` "12" `, version: 12},
+		{name: "weak tag", header: `W/"3"`, invalid: true},
+		{name: "unquoted", header: "3", invalid: true},
+		{name: "opening quote only", header: `"3`, invalid: true},
+		{name: "closing quote only", header: `3"`, invalid: true},
+		{name: "empty tag", header: `""`, invalid: true},
+		{name: "zero", header: `"0"`, invalid: true},
+		{name: "negative", header: `"-1"`, invalid: true},
+		{name: "not a number", header: `"abc"`, invalid: true},
+		{name: "list", header: `"3", "4"`, invalid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.header != "" {
+				r.Header.Set("If-Match", tt.header)
+			}
+			var v validator
+			version := v.ifMatch(r)
+			if version != tt.version {
+				t.Fatalf("want version %d, got %d", tt.version, version)
+			}
+			if invalid := !v.Valid(); invalid != tt.invalid {
+				t.Fatalf("want invalid %v, got fields %v", tt.invalid, v.Fields)
+			}
+			if tt.invalid && v.Fields[0].Field != "If-Match" {
+				t.Fatalf("want the If-Match field rejected, got %v", v.Fields)
+			}
+		})
+	}
+}
+
+func TestSetETagRoundTrip(t *testing.T) {
+	w := httptest.NewRecorder()
+	setETag(w, &models.PullRequest{Version: 7})
+	if etag := w.Header().Get("ETag"); etag != `"7"` {
+		t.Fatalf(`want ETag "7", got %s`, etag)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set("If-Match", w.Header().Get("ETag"))
+	var v validator
+	if version := v.ifMatch(r); version != 7 || !v.Valid() {
+		t.Fatalf("want version 7 back, got %d and fields %v", version, v.Fields)
+	}
+}
+
+func TestMergePRRejectsMalformedIfMatch(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", strings.NewReader(`{"pull_request_id": "pr-1"}`))
+	r.Header.Set("If-Match", `W/"1"`)
+
+	(&Handler{}).MergePR(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("want status 400, got %d", w.Code)
+	}
+	var resp models.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error.Code != models.ErrValidation || len(resp.Error.Fields) != 1 || resp.Error.Fields[0].Field != "If-Match" {
+		t.Fatalf("want VALIDATION_ERROR for If-Match, got %+v", resp.Error)
+	}
+}
+
+func TestVersionMismatchIsPreconditionFailed(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", nil)
+
+	(&Handler{}).respondDBError(w, r, "reassigning reviewer", apperr.ErrVersionMismatch.With("pull_request_id", "pr-1"))
+
+	if w.Code != http.StatusPreconditionFailed {
+		t.Fatalf("want status 412, got %d", w.Code)
+	}
+	var resp models.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error.Code != models.ErrPreconditionFailed {
+		t.Fatalf("want code %s, got %s", models.ErrPreconditionFailed, resp.Error.Code)
+	}
+}
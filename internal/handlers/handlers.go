@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
-	"strings"
+	"strconv"
+	"time"
 
 	"pr-review-service/internal/database"
 	"pr-review-service/internal/models"
+	"pr-review-service/internal/validate"
 )
 
 type Handler struct {
@@ -26,13 +28,8 @@ func (h *Handler) respondJSON(w http.ResponseWriter, status int, data interface{
 	}
 }
 
-func (h *Handler) respondError(w http.ResponseWriter, status int, code, message string) {
-	h.respondJSON(w, status, models.ErrorResponse{
-		Error: models.ErrorDetail{
-			Code:    code,
-			Message: message,
-		},
-	})
+func (h *Handler) respondError(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	h.writeError(w, r, status, models.ErrorDetail{Code: code, Message: message}, nil)
 }
 
 func (h *Handler) Health(w http.ResponseWriter, r *http.Request) {
@@ -42,18 +39,17 @@ func (h *Handler) Health(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) CreateTeam(w http.ResponseWriter, r *http.Request) {
 	var team models.Team
-	if err := json.NewDecoder(r.Body).Decode(&team); err != nil {
-		h.respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	if !h.decodeJSON(w, r, &team) {
+		return
+	}
+	var v validator
+	v.team(&team)
+	if !h.valid(w, r, &v) {
 		return
 	}
 
 	if err := h.db.CreateTeam(r.Context(), &team); err != nil {
-		if strings.Contains(err.Error(), models.ErrTeamExists) {
-			h.respondError(w, http.StatusBadRequest, models.ErrTeamExists, "team_name already exists")
-			return
-		}
-		log.Printf("Error creating team: %v", err)
-		h.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+		h.respondDBError(w, r, "creating team", err)
 		return
 	}
 
@@ -62,19 +58,17 @@ func (h *Handler) CreateTeam(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
 	teamName := r.URL.Query().Get("team_name")
-	if teamName == "" {
-		h.respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name is required")
+	var v validator
+	v.Required("team_name", teamName, validate.MaxNameLength)
+	if !h.valid(w, r, &v) {
 		return
 	}
 
-	team, err := h.db.GetTeam(r.Context(), teamName)
+	includeSubteams, _ := strconv.ParseBool(r.URL.Query().Get("include_subteams"))
+
+	team, err := h.db.GetTeam(r.Context(), teamName, includeSubteams)
 	if err != nil {
-		if strings.Contains(err.Error(), models.ErrNotFound) {
-			h.respondError(w, http.StatusNotFound, models.ErrNotFound, "team not found")
-			return
-		}
-		log.Printf("Error getting team: %v", err)
-		h.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+		h.respondDBError(w, r, "getting team", err)
 		return
 	}
 
@@ -84,56 +78,185 @@ func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) SetUserActive(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		UserID   string `json:"user_id"`
-		IsActive bool   `json:"is_active"`
+		IsActive *bool  `json:"is_active"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Required("user_id", req.UserID, validate.MaxIDLength)
+	v.Check(req.IsActive != nil, "is_active", "is required")
+	if !h.valid(w, r, &v) {
 		return
 	}
 
-	user, err := h.db.SetUserActive(r.Context(), req.UserID, req.IsActive)
+	user, err := h.db.SetUserActive(r.Context(), req.UserID, *req.IsActive)
 	if err != nil {
-		if strings.Contains(err.Error(), models.ErrNotFound) {
-			h.respondError(w, http.StatusNotFound, models.ErrNotFound, "user not found")
-			return
-		}
-		log.Printf("Error setting user active: %v", err)
-		h.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+		h.respondDBError(w, r, "setting user active", err)
 		return
 	}
 
 	h.respondJSON(w, http.StatusOK, map[string]interface{}{"user": user})
 }
 
-func (h *Handler) CreatePR(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		PullRequestID   string `json:"pull_request_id"`
-		PullRequestName string `json:"pull_request_name"`
-		AuthorID        string `json:"author_id"`
+type createPRRequest struct {
+	PullRequestID       string `json:"pull_request_id"`
+	PullRequestName     string `json:"pull_request_name"`
+	AuthorID            string `json:"author_id"`
+	Priority            string `json:"priority"`
+	RepositoryName      string `json:"repository_name"`
+	ParentPullRequestID string `json:"parent_pull_request_id"`
+	InheritReviewers    *bool  `json:"inherit_reviewers"`
+}
+
+// decodeCreatePR reads and validates a PR creation request, shared by v1 and v2.
+func (h *Handler) decodeCreatePR(w http.ResponseWriter, r *http.Request) (*createPRRequest, bool) {
+	var req createPRRequest
+	if !h.decodeJSON(w, r, &req) {
+		return nil, false
+	}
+	var v validator
+	req.validate(&v)
+	return &req, h.valid(w, r, &v)
+}
+
+// validate checks a PR creation request, defaulting the priority to normal.
+func (req *createPRRequest) validate(v *validator) {
+	if req.Priority == "" {
+		req.Priority = models.PriorityNormal
+	}
+	v.Required("pull_request_id", req.PullRequestID, validate.MaxIDLength)
+	v.Required("pull_request_name", req.PullRequestName, validate.MaxPRNameLength)
+	v.Required("author_id", req.AuthorID, validate.MaxIDLength)
+	v.Check(models.ValidPriority(req.Priority), "priority", "must be one of low, normal, high, hotfix")
+	v.MaxLength("repository_name", req.RepositoryName, validate.MaxNameLength)
+	v.MaxLength("parent_pull_request_id", req.ParentPullRequestID, validate.MaxIDLength)
+	v.Check(req.ParentPullRequestID == "" || req.ParentPullRequestID != req.PullRequestID, "parent_pull_request_id", "must differ from pull_request_id")
+}
+
+func (req *createPRRequest) pullRequest() *models.PullRequest {
+	return &models.PullRequest{
+		PullRequestID:       req.PullRequestID,
+		PullRequestName:     req.PullRequestName,
+		AuthorID:            req.AuthorID,
+		Priority:            req.Priority,
+		RepositoryName:      req.RepositoryName,
+		ParentPullRequestID: req.ParentPullRequestID,
 	}
+}
+
+func (req *createPRRequest) inheritReviewers() bool {
+	return req.InheritReviewers == nil || *req.InheritReviewers
+}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+func (h *Handler) createPR(r *http.Request, req *createPRRequest) (*models.PullRequest, error) {
+	return h.db.CreatePR(r.Context(), req.pullRequest(), req.inheritReviewers())
+}
+
+func (h *Handler) CreatePR(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.decodeCreatePR(w, r)
+	if !ok {
 		return
 	}
 
-	pr, err := h.db.CreatePR(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	pr, err := h.createPR(r, req)
 	if err != nil {
-		if strings.Contains(err.Error(), models.ErrPRExists) {
-			h.respondError(w, http.StatusConflict, models.ErrPRExists, "PR id already exists")
-			return
+		h.respondDBError(w, r, "creating PR", err)
+		return
+	}
+
+	setETag(w, pr)
+	h.respondJSON(w, http.StatusCreated, map[string]interface{}{"pr": pr})
+}
+
+const (
+	maxBatchSize = 500
+	// maxBatchBytes fits maxBatchSize items with every field at its maximum length in UTF-8.
+	maxBatchBytes = 4 << 20
+	// batchWriteTimeout replaces the server's WriteTimeout for a batch, which assigns reviewers
+	// item by item and can take well over the deadline meant for single requests.
+	batchWriteTimeout = 5 * time.Minute
+)
+
+// BatchCreatePRs registers many PRs at once, e.g. the open PRs of a newly onboarded repository.
+// Items are created in order, each on its own, so one invalid or conflicting PR, or a database
+// failure while creating it, does not fail the others; a stack parent may come earlier in the
+// same batch.
+func (h *Handler) BatchCreatePRs(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequests []createPRRequest `json:"pull_requests"`
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxBatchBytes)
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Check(len(req.PullRequests) > 0 && len(req.PullRequests) <= maxBatchSize, "pull_requests", "must contain between 1 and "+strconv.Itoa(maxBatchSize)+" items")
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	// Without this, a long batch would commit its items but never deliver their results.
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(batchWriteTimeout)); err != nil {
+		log.Printf("Batch create: cannot extend write deadline (request %s): %v", RequestID(r.Context()), err)
+	}
+
+	resp := models.BatchCreateResponse{Results: make([]models.BatchCreateResult, len(req.PullRequests))}
+	load := database.BatchLoad{}
+	for i := range req.PullRequests {
+		item := &req.PullRequests[i]
+		result := &resp.Results[i]
+		result.PullRequestID = item.PullRequestID
+
+		var v validator
+		item.validate(&v)
+		if !v.Valid() {
+			result.Status = http.StatusBadRequest
+			result.Error = &models.ErrorDetail{Code: models.ErrValidation, Message: "request validation failed", Fields: v.Fields}
+			resp.Failed++
+			continue
 		}
-		if strings.Contains(err.Error(), models.ErrNotFound) {
-			h.respondError(w, http.StatusNotFound, models.ErrNotFound, "author or team not found")
-			return
+
+		pr, err := h.db.CreateBatchPR(r.Context(), item.pullRequest(), item.inheritReviewers(), load)
+		if err != nil {
+			status, detail := itemError(r, "creating PR batch item "+item.PullRequestID, err)
+			result.Status, result.Error = status, &detail
+			resp.Failed++
+			continue
 		}
-		log.Printf("Error creating PR: %v", err)
-		h.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+		result.Status, result.PR = http.StatusCreated, pr
+		resp.Created++
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) GetPR(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	var v validator
+	v.Required("pull_request_id", prID, validate.MaxIDLength)
+	if !h.valid(w, r, &v) {
 		return
 	}
 
-	h.respondJSON(w, http.StatusCreated, map[string]interface{}{"pr": pr})
+	pr, err := h.db.GetPR(r.Context(), prID)
+	if err != nil {
+		h.respondDBError(w, r, "getting PR", err)
+		return
+	}
+
+	stack, err := h.db.GetPRStack(r.Context(), prID)
+	if err != nil {
+		h.respondDBError(w, r, "getting PR stack", err)
+		return
+	}
+
+	setETag(w, pr)
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr":    pr,
+		"stack": stack,
+	})
 }
 
 func (h *Handler) MergePR(w http.ResponseWriter, r *http.Request) {
@@ -141,22 +264,23 @@ func (h *Handler) MergePR(w http.ResponseWriter, r *http.Request) {
 		PullRequestID string `json:"pull_request_id"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Required("pull_request_id", req.PullRequestID, validate.MaxIDLength)
+	version := v.ifMatch(r)
+	if !h.valid(w, r, &v) {
 		return
 	}
 
-	pr, err := h.db.MergePR(r.Context(), req.PullRequestID)
+	pr, err := h.db.MergePR(r.Context(), req.PullRequestID, version)
 	if err != nil {
-		if strings.Contains(err.Error(), models.ErrNotFound) {
-			h.respondError(w, http.StatusNotFound, models.ErrNotFound, "PR not found")
-			return
-		}
-		log.Printf("Error merging PR: %v", err)
-		h.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+		h.respondDBError(w, r, "merging PR", err)
 		return
 	}
 
+	setETag(w, pr)
 	h.respondJSON(w, http.StatusOK, map[string]interface{}{"pr": pr})
 }
 
@@ -166,34 +290,24 @@ func (h *Handler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 		OldUserID     string `json:"old_user_id"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Required("pull_request_id", req.PullRequestID, validate.MaxIDLength)
+	v.Required("old_user_id", req.OldUserID, validate.MaxIDLength)
+	version := v.ifMatch(r)
+	if !h.valid(w, r, &v) {
 		return
 	}
 
-	pr, replacedBy, err := h.db.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID)
+	pr, replacedBy, err := h.db.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID, version)
 	if err != nil {
-		if strings.Contains(err.Error(), models.ErrPRMerged) {
-			h.respondError(w, http.StatusConflict, models.ErrPRMerged, "cannot reassign on merged PR")
-			return
-		}
-		if strings.Contains(err.Error(), models.ErrNotAssigned) {
-			h.respondError(w, http.StatusConflict, models.ErrNotAssigned, "reviewer is not assigned to this PR")
-			return
-		}
-		if strings.Contains(err.Error(), models.ErrNoCandidate) {
-			h.respondError(w, http.StatusConflict, models.ErrNoCandidate, "no active replacement candidate in team")
-			return
-		}
-		if strings.Contains(err.Error(), models.ErrNotFound) {
-			h.respondError(w, http.StatusNotFound, models.ErrNotFound, "PR or user not found")
-			return
-		}
-		log.Printf("Error reassigning reviewer: %v", err)
-		h.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+		h.respondDBError(w, r, "reassigning reviewer", err)
 		return
 	}
 
+	setETag(w, pr)
 	h.respondJSON(w, http.StatusOK, map[string]interface{}{
 		"pr":          pr,
 		"replaced_by": replacedBy,
@@ -202,15 +316,17 @@ func (h *Handler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) GetUserReviews(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
-	if userID == "" {
-		h.respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id is required")
+	status := r.URL.Query().Get("status")
+	var v validator
+	v.Required("user_id", userID, validate.MaxIDLength)
+	v.Check(status == "" || status == models.StatusOpen || status == models.StatusMerged, "status", "must be OPEN or MERGED")
+	if !h.valid(w, r, &v) {
 		return
 	}
 
-	prs, err := h.db.GetUserReviews(r.Context(), userID)
+	prs, err := h.db.GetUserReviews(r.Context(), userID, status)
 	if err != nil {
-		log.Printf("Error getting user reviews: %v", err)
-		h.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+		h.respondDBError(w, r, "getting user reviews", err)
 		return
 	}
 
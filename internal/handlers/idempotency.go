@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"pr-review-service/internal/models"
+	"pr-review-service/internal/validate"
+)
+
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks a response replayed from an earlier request with the same key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// replayedHeaders are the response headers stored with an idempotent response; the others
+// (request ID, Date, ...) belong to the retry itself.
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// Idempotency makes POST requests that carry an Idempotency-Key safe to retry: the first
+// response per key is stored for ttl and replayed to retries with the same method, path and
+// body, so e.g. a retried reassign does not pick a second replacement. Reusing a key for a
+// different request is rejected with 422, a retry while the first request still runs with 409.
+// Server errors are not stored, so the retry of a failed request runs again.
+func (h *Handler) Idempotency(ttl time.Duration, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		var v validator
+		v.MaxLength(IdempotencyKeyHeader, key, validate.MaxIDLength)
+		if !h.valid(w, r, &v) {
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportBytes))
+		if err != nil {
+			h.respondError(w, r, http.StatusBadRequest, models.ErrInvalidRequest, "Invalid request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		requestHash := hashRequest(r, body)
+
+		stored, claimed, err := h.db.ClaimIdempotencyKey(r.Context(), key, requestHash, ttl)
+		if err != nil {
+			h.respondDBError(w, r, "claiming idempotency key", err)
+			return
+		}
+		if !claimed {
+			h.replay(w, r, requestHash, stored)
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		// Store the outcome even if the client has gone away: that is exactly when it retries.
+		ctx := context.WithoutCancel(r.Context())
+		if rec.status >= http.StatusInternalServerError {
+			err = h.db.ReleaseIdempotencyKey(ctx, key, requestHash)
+		} else {
+			err = h.db.CompleteIdempotencyKey(ctx, key, rec.response(requestHash))
+		}
+		if err != nil {
+			log.Printf("Error storing idempotent response (request %s): %v", RequestID(r.Context()), err)
+		}
+	})
+}
+
+func (h *Handler) replay(w http.ResponseWriter, r *http.Request, requestHash string, stored *models.IdempotentResponse) {
+	switch {
+	case stored.RequestHash != requestHash:
+		h.respondError(w, r, http.StatusUnprocessableEntity, models.ErrIdempotencyReused,
+			"Idempotency-Key was already used for a different request")
+	case stored.Status == 0:
+		w.Header().Set("Retry-After", "1")
+		h.respondError(w, r, http.StatusConflict, models.ErrIdempotencyInUse,
+			"a request with this Idempotency-Key is still being processed")
+	default:
+		for name, value := range stored.Header {
+			w.Header().Set(name, value)
+		}
+		w.Header().Set(IdempotentReplayedHeader, "true")
+		w.WriteHeader(stored.Status)
+		w.Write(stored.Body)
+	}
+}
+
+// hashRequest fingerprints the method, path with query and body, so a key reused for another
+// endpoint counts as a different request too.
+func hashRequest(r *http.Request, body []byte) string {
+	sum := sha256.New()
+	io.WriteString(sum, r.Method+" "+r.URL.RequestURI()+"\n")
+	sum.Write(body)
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+// responseRecorder passes a response through while keeping a copy of it.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *responseRecorder) Write(p []byte) (int, error) {
+	rec.body.Write(p)
+	return rec.ResponseWriter.Write(p)
+}
+
+// Unwrap lets http.ResponseController reach the connection, e.g. to extend the write deadline.
+func (rec *responseRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+func (rec *responseRecorder) response(requestHash string) *models.IdempotentResponse {
+	header := make(map[string]string)
+	for _, name := range replayedHeaders {
+		if value := rec.Header().Get(name); value != "" {
+			header[name] = value
+		}
+	}
+	return &models.IdempotentResponse{
+		RequestHash: requestHash,
+		Status:      rec.status,
+		Header:      header,
+		Body:        rec.body.Bytes(),
+	}
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"pr-review-service/internal/models"
+)
+
+const problemContentType = "application/problem+json"
+
+// problemType is the type URI of an error code, e.g. /problems/pr-merged for PR_MERGED.
+func problemType(code string) string {
+	return "/problems/" + strings.ToLower(strings.ReplaceAll(code, "_", "-"))
+}
+
+// wantsProblem reports whether the client prefers RFC 7807 problem details to the default
+// error envelope, i.e. it accepts application/problem+json at least as much as application/json.
+func wantsProblem(r *http.Request) bool {
+	problemQ, jsonQ := 0.0, 0.0
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		switch mediaType {
+		case problemContentType:
+			problemQ = max(problemQ, q)
+		case "application/json":
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return problemQ > 0 && problemQ >= jsonQ
+}
+
+// writeError renders an error either as the models.ErrorResponse envelope or, when the client
+// asks for it, as problem details. fields become extension members of the problem, such as the
+// pull_request_id the error is about.
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, status int, detail models.ErrorDetail, fields map[string]string) {
+	if !wantsProblem(r) {
+		h.respondJSON(w, status, models.ErrorResponse{Error: detail})
+		return
+	}
+
+	title := http.StatusText(status)
+	if kind, ok := errorTypes[detail.Code]; ok {
+		title = kind.title
+	}
+	problem := map[string]interface{}{
+		"type":   problemType(detail.Code),
+		"title":  title,
+		"status": status,
+		"detail": detail.Message,
+		"code":   detail.Code,
+	}
+	if id := RequestID(r.Context()); id != "" {
+		problem["instance"] = "/requests/" + id
+	}
+	if len(detail.Fields) > 0 {
+		problem["fields"] = detail.Fields
+	}
+	if len(detail.Details) > 0 {
+		problem["details"] = detail.Details
+	}
+	for key, value := range fields {
+		if _, reserved := problem[key]; !reserved {
+			problem[key] = value
+		}
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		log.Printf("Error encoding response: %v", err)
+	}
+}
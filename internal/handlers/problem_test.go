@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"pr-review-service/internal/models"
+)
+
+func TestWantsProblem(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{accept: "", want: false},
+		{accept: "*/*", want: false},
+		{accept: "application/json", want: false},
+		{accept: "application/problem+json", want: true},
+		{accept: "application/problem+json, application/json", want: true},
+		{accept: "application/json, application/problem+json", want: true},
+		{accept: "application/json;q=0.9, application/problem+json", want: true},
+		{accept: "application/json, application/problem+json;q=0.5", want: false},
+		{accept: "application/problem+json;q=0.5, application/json;q=0.5", want: true},
+		{accept: "application/problem+json;q=0", want: false},
+		{accept: "application/problem+json;q=abc", want: false},
+		{accept: "application/problem+json; charset=utf-8", want: true},
+		{accept: "text/html, */*;q=0.8", want: false},
+		{accept: "not a media type, application/problem+json", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			if got := wantsProblem(r); got != tt.want {
+				t.Fatalf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWriteErrorEnvelope(t *testing.T) {
+	for _, accept := range []string{"", "*/*", "application/json"} {
+		t.Run(accept, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", nil)
+			r.Header.Set("Accept", accept)
+
+			(&Handler{}).writeError(w, r, http.StatusNotFound,
+				models.ErrorDetail{Code: models.ErrNotFound, Message: "PR not found"},
+				map[string]string{"pull_request_id": "pr-1"})
+
+			if w.Code != http.StatusNotFound {
+				t.Fatalf("want status 404, got %d", w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Fatalf("want Content-Type application/json, got %q", ct)
+			}
+			var body map[string]interface{}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			want := map[string]interface{}{
+				"error": map[string]interface{}{"code": models.ErrNotFound, "message": "PR not found"},
+			}
+			if !reflect.DeepEqual(body, want) {
+				t.Fatalf("want body %v, got %v", want, body)
+			}
+		})
+	}
+}
+
+func TestWriteErrorProblem(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", nil)
+	r.Header.Set("Accept", "application/problem+json")
+	r = r.WithContext(WithRequestID(r.Context(), "req-1"))
+
+	(&Handler{}).writeError(w, r, http.StatusConflict,
+		models.ErrorDetail{Code: models.ErrPRMerged, Message: "cannot reassign on merged PR"},
+		map[string]string{"pull_request_id": "pr-1", "status": "overridden"})
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("want status 409, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != problemContentType {
+		t.Fatalf("want Content-Type %s, got %q", problemContentType, ct)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	// Extension members never replace the standard ones, so "status" stays the HTTP status.
+	want := map[string]interface{}{
+		"type":            "/problems/pr-merged",
+		"title":           "Pull request is merged",
+		"status":          float64(http.StatusConflict),
+		"detail":          "cannot reassign on merged PR",
+		"code":            models.ErrPRMerged,
+		"instance":        "/requests/req-1",
+		"pull_request_id": "pr-1",
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Fatalf("want body %v, got %v", want, body)
+	}
+}
+
+func TestWriteErrorProblemFields(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/team/add", nil)
+	r.Header.Set("Accept", "application/problem+json")
+
+	(&Handler{}).writeError(w, r, http.StatusBadRequest, models.ErrorDetail{
+		Code:    models.ErrValidation,
+		Message: "request validation failed",
+		Fields:  []models.FieldError{{Field: "team_name", Message: "is required"}},
+	}, nil)
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := body["instance"]; ok {
+		t.Fatalf("want no instance without a request ID, got %v", body["instance"])
+	}
+	if body["type"] != "/problems/validation-error" || body["title"] != "Request validation failed" {
+		t.Fatalf("unexpected type and title in %v", body)
+	}
+	wantFields := []interface{}{map[string]interface{}{"field": "team_name", "message": "is required"}}
+	if !reflect.DeepEqual(body["fields"], wantFields) {
+		t.Fatalf("want fields %v, got %v", wantFields, body["fields"])
+	}
+}
+
+func TestWriteErrorProblemUnknownCode(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "application/problem+json")
+
+	(&Handler{}).writeError(w, r, http.StatusTeapot, models.ErrorDetail{Code: "SOMETHING_NEW", Message: "new"}, nil)
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["title"] != http.StatusText(http.StatusTeapot) || body["type"] != "/problems/something-new" {
+		t.Fatalf("want the status text as title, got %v", body)
+	}
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+
+	"pr-review-service/internal/models"
+	"pr-review-service/internal/validate"
+)
+
+func (h *Handler) validateRepository(w http.ResponseWriter, r *http.Request, repo *models.Repository) bool {
+	if repo.Strategy == "" {
+		repo.Strategy = models.StrategyRandom
+	}
+	var v validator
+	v.Required("repository_name", repo.RepositoryName, validate.MaxNameLength)
+	v.Required("team_name", repo.TeamName, validate.MaxNameLength)
+	v.Check(models.ValidStrategy(repo.Strategy), "strategy", "must be random or least_loaded")
+	v.Check(repo.ReviewerCount >= 0 && repo.ReviewerCount <= models.MaxReviewerCount, "reviewer_count", "must be between 0 and 10")
+	return h.valid(w, r, &v)
+}
+
+// repositoryUpdate checks the changed fields with the rules of validateRepository; an empty
+// strategy resets it to random, as on creation.
+func (v *validator) repositoryUpdate(update *models.RepositoryUpdate) {
+	if update.TeamName != nil {
+		v.Required("team_name", *update.TeamName, validate.MaxNameLength)
+	}
+	if update.Strategy != nil && *update.Strategy == "" {
+		strategy := models.StrategyRandom
+		update.Strategy = &strategy
+	}
+	if update.Strategy != nil {
+		v.Check(models.ValidStrategy(*update.Strategy), "strategy", "must be random or least_loaded")
+	}
+	if update.ReviewerCount != nil {
+		count := *update.ReviewerCount
+		v.Check(count >= 0 && count <= models.MaxReviewerCount, "reviewer_count", "must be between 0 and 10")
+	}
+}
+
+func (h *Handler) CreateRepository(w http.ResponseWriter, r *http.Request) {
+	repo := models.Repository{ReviewerCount: models.DefaultReviewerCount}
+	if !h.decodeJSON(w, r, &repo) {
+		return
+	}
+	if !h.validateRepository(w, r, &repo) {
+		return
+	}
+
+	if err := h.db.CreateRepository(r.Context(), &repo); err != nil {
+		h.respondDBError(w, r, "creating repository", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, map[string]interface{}{"repository": repo})
+}
+
+func (h *Handler) GetRepository(w http.ResponseWriter, r *http.Request) {
+	repositoryName := r.URL.Query().Get("repository_name")
+	var v validator
+	v.Required("repository_name", repositoryName, validate.MaxNameLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	repo, err := h.db.GetRepository(r.Context(), repositoryName)
+	if err != nil {
+		h.respondDBError(w, r, "getting repository", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, repo)
+}
+
+func (h *Handler) UpdateRepository(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RepositoryName string  `json:"repository_name"`
+		TeamName       *string `json:"team_name"`
+		ReviewerCount  *int    `json:"reviewer_count"`
+		Strategy       *string `json:"strategy"`
+	}
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	update := models.RepositoryUpdate{TeamName: req.TeamName, ReviewerCount: req.ReviewerCount, Strategy: req.Strategy}
+	var v validator
+	v.Required("repository_name", req.RepositoryName, validate.MaxNameLength)
+	v.repositoryUpdate(&update)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	repo, err := h.db.UpdateRepository(r.Context(), req.RepositoryName, &update)
+	if err != nil {
+		h.respondDBError(w, r, "updating repository", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"repository": repo})
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+
+	"pr-review-service/internal/validate"
+)
+
+func (h *Handler) SetTeamReviewSLA(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName       string `json:"team_name"`
+		ReviewSLAHours *int   `json:"review_sla_hours"`
+	}
+
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Required("team_name", req.TeamName, validate.MaxNameLength)
+	v.Check(req.ReviewSLAHours == nil || *req.ReviewSLAHours > 0, "review_sla_hours", "must be positive")
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	team, err := h.db.SetTeamReviewSLA(r.Context(), req.TeamName, req.ReviewSLAHours)
+	if err != nil {
+		h.respondDBError(w, r, "setting team review SLA", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"team": team})
+}
+
+func (h *Handler) RespondToReview(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+	}
+
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Required("pull_request_id", req.PullRequestID, validate.MaxIDLength)
+	v.Required("user_id", req.UserID, validate.MaxIDLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	review, err := h.db.RespondToReview(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		h.respondDBError(w, r, "recording review response", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"review": review})
+}
+
+func (h *Handler) GetOverduePRs(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	teamName := r.URL.Query().Get("team_name")
+	v.MaxLength("team_name", teamName, validate.MaxNameLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	prs, err := h.db.GetOverduePRs(r.Context(), teamName)
+	if err != nil {
+		h.respondDBError(w, r, "getting overdue PRs", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"pull_requests": prs})
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"pr-review-service/internal/models"
+	"pr-review-service/internal/validate"
+)
+
+func (h *Handler) AddTeamMembers(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName string              `json:"team_name"`
+		Members  []models.TeamMember `json:"members"`
+	}
+
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Required("team_name", req.TeamName, validate.MaxNameLength)
+	v.Check(len(req.Members) > 0, "members", "must not be empty")
+	v.members(req.Members)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	team, err := h.db.AddTeamMembers(r.Context(), req.TeamName, req.Members)
+	if err != nil {
+		h.respondDBError(w, r, "adding team members", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"team": team})
+}
+
+// SetMemberRole changes a member's role within one team. Leads are the escalation target
+// for overdue reviews; observers are notified but never picked as reviewers.
+func (h *Handler) SetMemberRole(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName string `json:"team_name"`
+		UserID   string `json:"user_id"`
+		Role     string `json:"role"`
+	}
+
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Required("team_name", req.TeamName, validate.MaxNameLength)
+	v.Required("user_id", req.UserID, validate.MaxIDLength)
+	v.Check(models.ValidRole(req.Role), "role", "must be one of lead, member, observer")
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	team, err := h.db.SetMemberRole(r.Context(), req.TeamName, req.UserID, req.Role)
+	if err != nil {
+		h.respondDBError(w, r, "setting member role", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"team": team})
+}
+
+func (h *Handler) RemoveTeamMember(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName string `json:"team_name"`
+		UserID   string `json:"user_id"`
+	}
+
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Required("team_name", req.TeamName, validate.MaxNameLength)
+	v.Required("user_id", req.UserID, validate.MaxIDLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	team, err := h.db.RemoveTeamMember(r.Context(), req.TeamName, req.UserID)
+	if err != nil {
+		h.respondDBError(w, r, "removing team member", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"team": team})
+}
+
+func (h *Handler) RenameTeam(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName    string `json:"team_name"`
+		NewTeamName string `json:"new_team_name"`
+	}
+
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Required("team_name", req.TeamName, validate.MaxNameLength)
+	v.Required("new_team_name", req.NewTeamName, validate.MaxNameLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	team, err := h.db.RenameTeam(r.Context(), req.TeamName, req.NewTeamName)
+	if err != nil {
+		h.respondDBError(w, r, "renaming team", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"team": team})
+}
+
+func (h *Handler) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName string `json:"team_name"`
+		Force    bool   `json:"force"`
+	}
+
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Required("team_name", req.TeamName, validate.MaxNameLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	if err := h.db.DeleteTeam(r.Context(), req.TeamName, req.Force); err != nil {
+		h.respondDBError(w, r, "deleting team", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"team_name": req.TeamName})
+}
+
+func (h *Handler) SetTeamParent(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName       string  `json:"team_name"`
+		ParentTeamName *string `json:"parent_team_name"`
+	}
+
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Required("team_name", req.TeamName, validate.MaxNameLength)
+	if req.ParentTeamName != nil {
+		v.Required("parent_team_name", *req.ParentTeamName, validate.MaxNameLength)
+	}
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	team, err := h.db.SetTeamParent(r.Context(), req.TeamName, req.ParentTeamName)
+	if err != nil {
+		h.respondDBError(w, r, "setting team parent", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"team": team})
+}
+
+func (h *Handler) GetTeamTree(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+
+	tree, err := h.db.GetTeamTree(r.Context(), teamName)
+	if err != nil {
+		h.respondDBError(w, r, "getting team tree", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"teams": tree})
+}
+
+func (h *Handler) GetTeamStats(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	var v validator
+	v.Required("team_name", teamName, validate.MaxNameLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+	includeSubteams, _ := strconv.ParseBool(r.URL.Query().Get("include_subteams"))
+
+	stats, err := h.db.GetTeamStats(r.Context(), teamName, includeSubteams)
+	if err != nil {
+		h.respondDBError(w, r, "getting team stats", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, stats)
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"pr-review-service/internal/models"
+	"pr-review-service/internal/validate"
+)
+
+func (h *Handler) MoveUserToTeam(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID       string `json:"user_id"`
+		FromTeamName string `json:"from_team_name"`
+		TeamName     string `json:"team_name"`
+	}
+
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Required("user_id", req.UserID, validate.MaxIDLength)
+	v.MaxLength("from_team_name", req.FromTeamName, validate.MaxNameLength)
+	v.Required("team_name", req.TeamName, validate.MaxNameLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	user, err := h.db.MoveUserToTeam(r.Context(), req.UserID, req.FromTeamName, req.TeamName)
+	if err != nil {
+		h.respondDBError(w, r, "moving user to team", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"user": user})
+}
+
+func (h *Handler) SetPrimaryTeam(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID   string `json:"user_id"`
+		TeamName string `json:"team_name"`
+	}
+
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Required("user_id", req.UserID, validate.MaxIDLength)
+	v.Required("team_name", req.TeamName, validate.MaxNameLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	user, err := h.db.SetPrimaryTeam(r.Context(), req.UserID, req.TeamName)
+	if err != nil {
+		h.respondDBError(w, r, "setting primary team", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"user": user})
+}
+
+func (h *Handler) GetTeamHistory(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	var v validator
+	v.Required("user_id", userID, validate.MaxIDLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	history, err := h.db.GetTeamHistory(r.Context(), userID)
+	if err != nil {
+		h.respondDBError(w, r, "getting team history", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id": userID,
+		"history": history,
+	})
+}
+
+func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	var v validator
+	v.Required("user_id", userID, validate.MaxIDLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	profile, err := h.db.GetUserProfile(r.Context(), userID)
+	if err != nil {
+		h.respondDBError(w, r, "getting user", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"user": profile})
+}
+
+func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	filter, ok := h.userFilter(w, r)
+	if !ok {
+		return
+	}
+
+	users, total, err := h.db.ListUsers(r.Context(), filter)
+	if err != nil {
+		h.respondDBError(w, r, "listing users", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"users":  users,
+		"total":  total,
+		"limit":  filter.Limit,
+		"offset": filter.Offset,
+	})
+}
+
+func (h *Handler) SetUserSkills(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID string   `json:"user_id"`
+		Skills []string `json:"skills"`
+	}
+
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Required("user_id", req.UserID, validate.MaxIDLength)
+	for i, skill := range req.Skills {
+		v.Required(fmt.Sprintf("skills[%d]", i), skill, validate.MaxSkillLength)
+	}
+	v.Unique("skills", "", req.Skills)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	profile, err := h.db.SetUserSkills(r.Context(), req.UserID, req.Skills)
+	if err != nil {
+		h.respondDBError(w, r, "setting user skills", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{"user": profile})
+}
+
+// userFilter parses the user list query parameters shared by /users/list and GET /v2/users.
+func (h *Handler) userFilter(w http.ResponseWriter, r *http.Request) (models.UserFilter, bool) {
+	query := r.URL.Query()
+	filter := models.UserFilter{
+		TeamName: query.Get("team_name"),
+		Skill:    query.Get("skill"),
+		Search:   query.Get("search"),
+	}
+
+	var v validator
+	if value := query.Get("include_subteams"); value != "" {
+		var err error
+		filter.IncludeSubteams, err = strconv.ParseBool(value)
+		v.Check(err == nil, "include_subteams", "must be a boolean")
+	}
+	if value := query.Get("is_active"); value != "" {
+		isActive, err := strconv.ParseBool(value)
+		v.Check(err == nil, "is_active", "must be a boolean")
+		filter.IsActive = &isActive
+	}
+	filter.Limit, filter.Offset = v.page(query)
+	v.MaxLength("team_name", filter.TeamName, validate.MaxNameLength)
+	v.MaxLength("skill", filter.Skill, validate.MaxSkillLength)
+	return filter, h.valid(w, r, &v)
+}
+
+// OffboardUser soft-deletes a user, keeping their PR and review history and handing over OPEN reviews.
+func (h *Handler) OffboardUser(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID string `json:"user_id"`
+	}
+
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	v.Required("user_id", req.UserID, validate.MaxIDLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	result, err := h.db.OffboardUser(r.Context(), req.UserID)
+	if err != nil {
+		h.respondDBError(w, r, "offboarding user", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,637 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"pr-review-service/internal/models"
+	"pr-review-service/internal/validate"
+)
+
+// The v2 API is resource oriented: identifiers come from the path (r.PathValue), responses are
+// the bare resource rather than a {"team": ...} wrapper, and creations answer 201 with Location.
+// Request validation and error responses are shared with v1.
+
+// V2Prefix is the path prefix of every v2 route.
+const V2Prefix = "/v2"
+
+// nullable distinguishes an absent JSON field (Set is false) from an explicit null (Value is nil).
+type nullable[T any] struct {
+	Set   bool
+	Value *T
+}
+
+func (n *nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if string(data) == "null" {
+		n.Value = nil
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	n.Value = &value
+	return nil
+}
+
+type listResponse struct {
+	Items  interface{} `json:"items"`
+	Total  int         `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
+
+func (h *Handler) respondCreated(w http.ResponseWriter, location string, data interface{}) {
+	w.Header().Set("Location", location)
+	h.respondJSON(w, http.StatusCreated, data)
+}
+
+func v2Path(collection, id string) string {
+	return V2Prefix + "/" + collection + "/" + url.PathEscape(id)
+}
+
+// pathTeam reads and validates the {team_name} path segment.
+func pathTeam(r *http.Request, v *validator) string {
+	teamName := r.PathValue("team_name")
+	v.Required("team_name", teamName, validate.MaxNameLength)
+	return teamName
+}
+
+func pathUser(r *http.Request, v *validator) string {
+	userID := r.PathValue("user_id")
+	v.Required("user_id", userID, validate.MaxIDLength)
+	return userID
+}
+
+func pathPR(r *http.Request, v *validator) string {
+	prID := r.PathValue("pull_request_id")
+	v.Required("pull_request_id", prID, validate.MaxIDLength)
+	return prID
+}
+
+// Teams
+
+func (h *Handler) V2CreateTeam(w http.ResponseWriter, r *http.Request) {
+	var team models.Team
+	if !h.decodeJSON(w, r, &team) {
+		return
+	}
+	var v validator
+	v.team(&team)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	if err := h.db.CreateTeam(r.Context(), &team); err != nil {
+		h.respondDBError(w, r, "creating team", err)
+		return
+	}
+
+	h.respondCreated(w, v2Path("teams", team.TeamName), team)
+}
+
+func (h *Handler) V2ListTeams(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	limit, offset := v.page(r.URL.Query())
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	teamNames, total, err := h.db.ListTeamNames(r.Context(), limit, offset)
+	if err != nil {
+		h.respondDBError(w, r, "listing teams", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, listResponse{Items: teamNames, Total: total, Limit: limit, Offset: offset})
+}
+
+func (h *Handler) V2GetTeam(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	teamName := pathTeam(r, &v)
+	includeSubteams := queryBool(r, &v, "include_subteams")
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	team, err := h.db.GetTeam(r.Context(), teamName, includeSubteams)
+	if err != nil {
+		h.respondDBError(w, r, "getting team", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, team)
+}
+
+// V2UpdateTeam changes the review SLA, the parent team and the name, all or nothing. An explicit
+// null clears the SLA or detaches the team from its parent.
+func (h *Handler) V2UpdateTeam(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName       *string          `json:"team_name"`
+		ParentTeamName nullable[string] `json:"parent_team_name"`
+		ReviewSLAHours nullable[int]    `json:"review_sla_hours"`
+	}
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	teamName := pathTeam(r, &v)
+	if req.TeamName != nil {
+		v.Required("team_name", *req.TeamName, validate.MaxNameLength)
+	}
+	if req.ParentTeamName.Value != nil {
+		v.Required("parent_team_name", *req.ParentTeamName.Value, validate.MaxNameLength)
+	}
+	v.Check(req.ReviewSLAHours.Value == nil || *req.ReviewSLAHours.Value > 0, "review_sla_hours", "must be positive")
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	team, err := h.db.UpdateTeam(r.Context(), teamName, &models.TeamUpdate{
+		TeamName:       req.TeamName,
+		SetParent:      req.ParentTeamName.Set,
+		ParentTeamName: req.ParentTeamName.Value,
+		SetReviewSLA:   req.ReviewSLAHours.Set,
+		ReviewSLAHours: req.ReviewSLAHours.Value,
+	})
+	if err != nil {
+		h.respondDBError(w, r, "updating team", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, team)
+}
+
+func (h *Handler) V2DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	teamName := pathTeam(r, &v)
+	force := queryBool(r, &v, "force")
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	if err := h.db.DeleteTeam(r.Context(), teamName, force); err != nil {
+		h.respondDBError(w, r, "deleting team", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) V2AddTeamMembers(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Members []models.TeamMember `json:"members"`
+	}
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	teamName := pathTeam(r, &v)
+	v.Check(len(req.Members) > 0, "members", "must not be empty")
+	v.members(req.Members)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	team, err := h.db.AddTeamMembers(r.Context(), teamName, req.Members)
+	if err != nil {
+		h.respondDBError(w, r, "adding team members", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, team)
+}
+
+func (h *Handler) V2UpdateTeamMember(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Role string `json:"role"`
+	}
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	teamName := pathTeam(r, &v)
+	userID := pathUser(r, &v)
+	v.Check(models.ValidRole(req.Role), "role", "must be one of lead, member, observer")
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	team, err := h.db.SetMemberRole(r.Context(), teamName, userID, req.Role)
+	if err != nil {
+		h.respondDBError(w, r, "setting member role", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, team)
+}
+
+func (h *Handler) V2RemoveTeamMember(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	teamName := pathTeam(r, &v)
+	userID := pathUser(r, &v)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	team, err := h.db.RemoveTeamMember(r.Context(), teamName, userID)
+	if err != nil {
+		h.respondDBError(w, r, "removing team member", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, team)
+}
+
+func (h *Handler) V2GetTeamTree(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	teamName := pathTeam(r, &v)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	tree, err := h.db.GetTeamTree(r.Context(), teamName)
+	if err != nil {
+		h.respondDBError(w, r, "getting team tree", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, tree)
+}
+
+func (h *Handler) V2GetTeamStats(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	teamName := pathTeam(r, &v)
+	includeSubteams := queryBool(r, &v, "include_subteams")
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	stats, err := h.db.GetTeamStats(r.Context(), teamName, includeSubteams)
+	if err != nil {
+		h.respondDBError(w, r, "getting team stats", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, stats)
+}
+
+// Users
+
+func (h *Handler) V2ListUsers(w http.ResponseWriter, r *http.Request) {
+	filter, ok := h.userFilter(w, r)
+	if !ok {
+		return
+	}
+
+	users, total, err := h.db.ListUsers(r.Context(), filter)
+	if err != nil {
+		h.respondDBError(w, r, "listing users", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, listResponse{Items: users, Total: total, Limit: filter.Limit, Offset: filter.Offset})
+}
+
+func (h *Handler) V2GetUser(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	userID := pathUser(r, &v)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	profile, err := h.db.GetUserProfile(r.Context(), userID)
+	if err != nil {
+		h.respondDBError(w, r, "getting user", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, profile)
+}
+
+// V2UpdateUser applies the given fields, all or nothing, and returns the resulting profile.
+func (h *Handler) V2UpdateUser(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Username        *string   `json:"username"`
+		IsActive        *bool     `json:"is_active"`
+		PrimaryTeamName *string   `json:"primary_team_name"`
+		Skills          *[]string `json:"skills"`
+	}
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	userID := pathUser(r, &v)
+	if req.Username != nil {
+		v.Required("username", *req.Username, validate.MaxNameLength)
+	}
+	if req.PrimaryTeamName != nil {
+		v.Required("primary_team_name", *req.PrimaryTeamName, validate.MaxNameLength)
+	}
+	if req.Skills != nil {
+		for i, skill := range *req.Skills {
+			v.Required("skills["+strconv.Itoa(i)+"]", skill, validate.MaxSkillLength)
+		}
+		v.Unique("skills", "", *req.Skills)
+	}
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	profile, err := h.db.UpdateUser(r.Context(), userID, &models.UserUpdate{
+		Username:        req.Username,
+		IsActive:        req.IsActive,
+		PrimaryTeamName: req.PrimaryTeamName,
+		Skills:          req.Skills,
+	})
+	if err != nil {
+		h.respondDBError(w, r, "updating user", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, profile)
+}
+
+// V2DeleteUser offboards the user; the row stays as a tombstone, see OffboardUser.
+func (h *Handler) V2DeleteUser(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	userID := pathUser(r, &v)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	result, err := h.db.OffboardUser(r.Context(), userID)
+	if err != nil {
+		h.respondDBError(w, r, "offboarding user", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) V2MoveUser(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		FromTeamName string `json:"from_team_name"`
+		TeamName     string `json:"team_name"`
+	}
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	userID := pathUser(r, &v)
+	v.MaxLength("from_team_name", req.FromTeamName, validate.MaxNameLength)
+	v.Required("team_name", req.TeamName, validate.MaxNameLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	user, err := h.db.MoveUserToTeam(r.Context(), userID, req.FromTeamName, req.TeamName)
+	if err != nil {
+		h.respondDBError(w, r, "moving user to team", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, user)
+}
+
+func (h *Handler) V2GetUserReviews(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	userID := pathUser(r, &v)
+	status := r.URL.Query().Get("status")
+	v.Check(status == "" || status == models.StatusOpen || status == models.StatusMerged, "status", "must be OPEN or MERGED")
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	reviews, err := h.db.GetUserReviews(r.Context(), userID, status)
+	if err != nil {
+		h.respondDBError(w, r, "getting user reviews", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, reviews)
+}
+
+func (h *Handler) V2GetTeamHistory(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	userID := pathUser(r, &v)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	history, err := h.db.GetTeamHistory(r.Context(), userID)
+	if err != nil {
+		h.respondDBError(w, r, "getting team history", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, history)
+}
+
+// Pull requests
+
+type pullRequestWithStack struct {
+	*models.PullRequest
+	Stack []models.StackEntry `json:"stack"`
+}
+
+func (h *Handler) V2CreatePR(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.decodeCreatePR(w, r)
+	if !ok {
+		return
+	}
+
+	pr, err := h.createPR(r, req)
+	if err != nil {
+		h.respondDBError(w, r, "creating PR", err)
+		return
+	}
+
+	setETag(w, pr)
+	h.respondCreated(w, v2Path("pull-requests", pr.PullRequestID), pr)
+}
+
+func (h *Handler) V2GetPR(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	prID := pathPR(r, &v)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	pr, err := h.db.GetPR(r.Context(), prID)
+	if err != nil {
+		h.respondDBError(w, r, "getting PR", err)
+		return
+	}
+	stack, err := h.db.GetPRStack(r.Context(), prID)
+	if err != nil {
+		h.respondDBError(w, r, "getting PR stack", err)
+		return
+	}
+
+	setETag(w, pr)
+	h.respondJSON(w, http.StatusOK, pullRequestWithStack{PullRequest: pr, Stack: stack})
+}
+
+func (h *Handler) V2MergePR(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	prID := pathPR(r, &v)
+	version := v.ifMatch(r)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	pr, err := h.db.MergePR(r.Context(), prID, version)
+	if err != nil {
+		h.respondDBError(w, r, "merging PR", err)
+		return
+	}
+
+	setETag(w, pr)
+	h.respondJSON(w, http.StatusOK, pr)
+}
+
+func (h *Handler) V2ReassignReviewer(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		OldUserID string `json:"old_user_id"`
+	}
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	prID := pathPR(r, &v)
+	v.Required("old_user_id", req.OldUserID, validate.MaxIDLength)
+	version := v.ifMatch(r)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	pr, replacedBy, err := h.db.ReassignReviewer(r.Context(), prID, req.OldUserID, version)
+	if err != nil {
+		h.respondDBError(w, r, "reassigning reviewer", err)
+		return
+	}
+
+	setETag(w, pr)
+	h.respondJSON(w, http.StatusOK, struct {
+		*models.PullRequest
+		ReplacedBy string `json:"replaced_by"`
+	}{pr, replacedBy})
+}
+
+func (h *Handler) V2RespondToReview(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID string `json:"user_id"`
+	}
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	var v validator
+	prID := pathPR(r, &v)
+	v.Required("user_id", req.UserID, validate.MaxIDLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	review, err := h.db.RespondToReview(r.Context(), prID, req.UserID)
+	if err != nil {
+		h.respondDBError(w, r, "recording review response", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, review)
+}
+
+func (h *Handler) V2GetOverduePRs(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	teamName := r.URL.Query().Get("team_name")
+	v.MaxLength("team_name", teamName, validate.MaxNameLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	prs, err := h.db.GetOverduePRs(r.Context(), teamName)
+	if err != nil {
+		h.respondDBError(w, r, "getting overdue PRs", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, prs)
+}
+
+// Repositories
+
+func (h *Handler) V2CreateRepository(w http.ResponseWriter, r *http.Request) {
+	repo := models.Repository{ReviewerCount: models.DefaultReviewerCount}
+	if !h.decodeJSON(w, r, &repo) {
+		return
+	}
+	if !h.validateRepository(w, r, &repo) {
+		return
+	}
+
+	if err := h.db.CreateRepository(r.Context(), &repo); err != nil {
+		h.respondDBError(w, r, "creating repository", err)
+		return
+	}
+
+	h.respondCreated(w, v2Path("repositories", repo.RepositoryName), repo)
+}
+
+func (h *Handler) V2GetRepository(w http.ResponseWriter, r *http.Request) {
+	var v validator
+	repositoryName := r.PathValue("repository_name")
+	v.Required("repository_name", repositoryName, validate.MaxNameLength)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	repo, err := h.db.GetRepository(r.Context(), repositoryName)
+	if err != nil {
+		h.respondDBError(w, r, "getting repository", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, repo)
+}
+
+func (h *Handler) V2UpdateRepository(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName      *string `json:"team_name"`
+		ReviewerCount *int    `json:"reviewer_count"`
+		Strategy      *string `json:"strategy"`
+	}
+	if !h.decodeJSON(w, r, &req) {
+		return
+	}
+	update := models.RepositoryUpdate{TeamName: req.TeamName, ReviewerCount: req.ReviewerCount, Strategy: req.Strategy}
+	var v validator
+	repositoryName := r.PathValue("repository_name")
+	v.Required("repository_name", repositoryName, validate.MaxNameLength)
+	v.repositoryUpdate(&update)
+	if !h.valid(w, r, &v) {
+		return
+	}
+
+	repo, err := h.db.UpdateRepository(r.Context(), repositoryName, &update)
+	if err != nil {
+		h.respondDBError(w, r, "updating repository", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, repo)
+}
+
+// queryBool parses an optional boolean query parameter; absent means false.
+func queryBool(r *http.Request, v *validator, name string) bool {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false
+	}
+	parsed, err := strconv.ParseBool(value)
+	v.Check(err == nil, name, "must be a boolean")
+	return parsed
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"pr-review-service/internal/models"
+	"pr-review-service/internal/validate"
+)
+
+// validator adds the checks of HTTP request shapes to the shared field rules; valid renders the
+// collected fields as VALIDATION_ERROR.
+type validator struct {
+	validate.Validator
+}
+
+// page reads the limit and offset query parameters, defaulting to the first DefaultPageLimit
+// items. A value that is not a number is reported like one out of range.
+func (v *validator) page(query url.Values) (limit, offset int) {
+	limit = validate.DefaultPageLimit
+	var err error
+	if value := query.Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil {
+			limit = -1
+		}
+	}
+	if value := query.Get("offset"); value != "" {
+		if offset, err = strconv.Atoi(value); err != nil {
+			offset = -1
+		}
+	}
+	v.Page(limit, offset)
+	return limit, offset
+}
+
+// team validates a team as accepted by POST /team/add and POST /v2/teams.
+func (v *validator) team(team *models.Team) {
+	v.Required("team_name", team.TeamName, validate.MaxNameLength)
+	if team.ParentTeamName != nil {
+		v.Required("parent_team_name", *team.ParentTeamName, validate.MaxNameLength)
+	}
+	v.Check(team.ReviewSLAHours == nil || *team.ReviewSLAHours > 0, "review_sla_hours", "must be positive")
+	v.members(team.Members)
+}
+
+// members validates team members as accepted by /team/add and /team/addMembers.
+func (v *validator) members(members []models.TeamMember) {
+	userIDs := make([]string, 0, len(members))
+	for i, member := range members {
+		prefix := fmt.Sprintf("members[%d].", i)
+		v.Required(prefix+"user_id", member.UserID, validate.MaxIDLength)
+		v.Required(prefix+"username", member.Username, validate.MaxNameLength)
+		// An empty role keeps the current role or defaults to member.
+		v.Check(member.Role == "" || models.ValidRole(member.Role), prefix+"role", "must be one of lead, member, observer")
+		userIDs = append(userIDs, member.UserID)
+	}
+	v.Unique("members", ".user_id", userIDs)
+}
+
+// valid responds with VALIDATION_ERROR listing the collected fields, if any.
+func (h *Handler) valid(w http.ResponseWriter, r *http.Request, v *validator) bool {
+	if v.Valid() {
+		return true
+	}
+	h.writeError(w, r, http.StatusBadRequest, models.ErrorDetail{
+		Code:    models.ErrValidation,
+		Message: "request validation failed",
+		Fields:  v.Fields,
+	}, nil)
+	return false
+}
+
+// decodeJSON decodes a single JSON object into dst, rejecting unknown fields and values of the
+// wrong type with VALIDATION_ERROR and malformed bodies with INVALID_REQUEST.
+func (h *Handler) decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(dst)
+	if err == nil && dec.Decode(&struct{}{}) != io.EOF {
+		err = errors.New("trailing data after JSON object")
+	}
+	if err == nil {
+		return true
+	}
+
+	var v validator
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "body"
+		}
+		v.Add(field, "must be of type "+jsonType(typeErr.Type.Kind().String()))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		v.Add(strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`), "unknown field")
+	default:
+		h.respondError(w, r, http.StatusBadRequest, models.ErrInvalidRequest, "Invalid request body")
+		return false
+	}
+	return h.valid(w, r, &v)
+}
+
+func jsonType(kind string) string {
+	switch {
+	case kind == "string":
+		return "string"
+	case kind == "bool":
+		return "boolean"
+	case strings.HasPrefix(kind, "int"), strings.HasPrefix(kind, "uint"), strings.HasPrefix(kind, "float"):
+		return "number"
+	case kind == "slice", kind == "array":
+		return "array"
+	default:
+		return "object"
+	}
+}
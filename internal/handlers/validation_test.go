@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"pr-review-service/internal/models"
+	"pr-review-service/internal/validate"
+)
+
+// fieldNames lists the rejected fields in the order they were reported.
+func fieldNames(v *validator) []string {
+	names := []string{}
+	for _, field := range v.Fields {
+		names = append(names, field.Field)
+	}
+	return names
+}
+
+func TestValidatorPage(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		limit  int
+		offset int
+		fields []string
+	}{
+		{name: "defaults", query: "", limit: validate.DefaultPageLimit, fields: []string{}},
+		{name: "smallest limit", query: "limit=1&offset=0", limit: 1, fields: []string{}},
+		{name: "largest limit", query: "limit=200&offset=10", limit: 200, offset: 10, fields: []string{}},
+		{name: "zero limit", query: "limit=0", limit: 0, fields: []string{"limit"}},
+		{name: "limit over maximum", query: "limit=201", limit: 201, fields: []string{"limit"}},
+		{name: "negative offset", query: "offset=-1", limit: validate.DefaultPageLimit, offset: -1, fields: []string{"offset"}},
+		{name: "not numbers", query: "limit=ten&offset=x", limit: -1, offset: -1, fields: []string{"limit", "offset"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var v validator
+			limit, offset := v.page(query)
+			if limit != tt.limit || offset != tt.offset {
+				t.Fatalf("want limit %d offset %d, got %d %d", tt.limit, tt.offset, limit, offset)
+			}
+			if got := fieldNames(&v); !reflect.DeepEqual(got, tt.fields) {
+				t.Fatalf("want fields %q, got %q", tt.fields, got)
+			}
+		})
+	}
+}
+
+func TestValidatorTeam(t *testing.T) {
+	hours := func(h int) *int { return &h }
+	name := func(s string) *string { return &s }
+	member := models.TeamMember{UserID: "u1", Username: "Alice", IsActive: true}
+
+	tests := []struct {
+		name   string
+		team   models.Team
+		fields []string
+	}{
+		{name: "minimal", team: models.Team{TeamName: "backend"}, fields: []string{}},
+		{name: "longest name", team: models.Team{TeamName: strings.Repeat("a", validate.MaxNameLength)}, fields: []string{}},
+		{name: "longest name in runes", team: models.Team{TeamName: strings.Repeat("я", validate.MaxNameLength)}, fields: []string{}},
+		{name: "name too long", team: models.Team{TeamName: strings.Repeat("a", validate.MaxNameLength+1)}, fields: []string{"team_name"}},
+		{name: "missing name", team: models.Team{}, fields: []string{"team_name"}},
+		{name: "empty parent", team: models.Team{TeamName: "backend", ParentTeamName: name("")}, fields: []string{"parent_team_name"}},
+		{name: "smallest SLA", team: models.Team{TeamName: "backend", ReviewSLAHours: hours(1)}, fields: []string{}},
+		{name: "zero SLA", team: models.Team{TeamName: "backend", ReviewSLAHours: hours(0)}, fields: []string{"review_sla_hours"}},
+		{name: "members", team: models.Team{TeamName: "backend", Members: []models.TeamMember{member}}, fields: []string{}},
+		{
+			name:   "invalid member",
+			team:   models.Team{TeamName: "backend", Members: []models.TeamMember{member, {Role: "owner"}}},
+			fields: []string{"members[1].user_id", "members[1].username", "members[1].role"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v validator
+			v.team(&tt.team)
+			if got := fieldNames(&v); !reflect.DeepEqual(got, tt.fields) {
+				t.Fatalf("want fields %q, got %q", tt.fields, got)
+			}
+		})
+	}
+}
+
+func TestValidatorMembers(t *testing.T) {
+	tests := []struct {
+		name    string
+		members []models.TeamMember
+		fields  []string
+	}{
+		{name: "none", members: nil, fields: []string{}},
+		{
+			name: "every role",
+			members: []models.TeamMember{
+				{UserID: "u1", Username: "a", Role: models.RoleLead},
+				{UserID: "u2", Username: "b", Role: models.RoleMember},
+				{UserID: "u3", Username: "c", Role: models.RoleObserver},
+				{UserID: "u4", Username: "d"},
+			},
+			fields: []string{},
+		},
+		{
+			name: "longest values",
+			members: []models.TeamMember{
+				{UserID: strings.Repeat("u", validate.MaxIDLength), Username: strings.Repeat("n", validate.MaxNameLength)},
+			},
+			fields: []string{},
+		},
+		{
+			name: "values too long",
+			members: []models.TeamMember{
+				{UserID: strings.Repeat("u", validate.MaxIDLength+1), Username: strings.Repeat("n", validate.MaxNameLength+1)},
+			},
+			fields: []string{"members[0].user_id", "members[0].username"},
+		},
+		{
+			name: "duplicates",
+			members: []models.TeamMember{
+				{UserID: "u1", Username: "a"},
+				{UserID: "u2", Username: "b"},
+				{UserID: "u1", Username: "c"},
+				{UserID: "u1", Username: "d"},
+			},
+			fields: []string{"members[2].user_id", "members[3].user_id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v validator
+			v.members(tt.members)
+			if got := fieldNames(&v); !reflect.DeepEqual(got, tt.fields) {
+				t.Fatalf("want fields %q, got %q", tt.fields, got)
+			}
+		})
+	}
+}
+
+func TestCreatePRRequestValidate(t *testing.T) {
+	valid := func() createPRRequest {
+		return createPRRequest{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1"}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(req *createPRRequest)
+		fields []string
+	}{
+		{name: "minimal", modify: func(req *createPRRequest) {}, fields: []string{}},
+		{
+			name: "longest values",
+			modify: func(req *createPRRequest) {
+				req.PullRequestID = strings.Repeat("p", validate.MaxIDLength)
+				req.PullRequestName = strings.Repeat("n", validate.MaxPRNameLength)
+				req.AuthorID = strings.Repeat("a", validate.MaxIDLength)
+				req.RepositoryName = strings.Repeat("r", validate.MaxNameLength)
+				req.ParentPullRequestID = strings.Repeat("q", validate.MaxIDLength)
+			},
+			fields: []string{},
+		},
+		{
+			name: "values too long",
+			modify: func(req *createPRRequest) {
+				req.PullRequestID = strings.Repeat("p", validate.MaxIDLength+1)
+				req.PullRequestName = strings.Repeat("n", validate.MaxPRNameLength+1)
+				req.AuthorID = strings.Repeat("a", validate.MaxIDLength+1)
+				req.RepositoryName = strings.Repeat("r", validate.MaxNameLength+1)
+				req.ParentPullRequestID = strings.Repeat("q", validate.MaxIDLength+1)
+			},
+			fields: []string{"pull_request_id", "pull_request_name", "author_id", "repository_name", "parent_pull_request_id"},
+		},
+		{
+			name:   "missing required",
+			modify: func(req *createPRRequest) { *req = createPRRequest{} },
+			fields: []string{"pull_request_id", "pull_request_name", "author_id"},
+		},
+		{name: "hotfix", modify: func(req *createPRRequest) { req.Priority = models.PriorityHotfix }, fields: []string{}},
+		{name: "unknown priority", modify: func(req *createPRRequest) { req.Priority = "urgent" }, fields: []string{"priority"}},
+		{name: "own parent", modify: func(req *createPRRequest) { req.ParentPullRequestID = "pr-1" }, fields: []string{"parent_pull_request_id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(&req)
+			var v validator
+			req.validate(&v)
+			if got := fieldNames(&v); !reflect.DeepEqual(got, tt.fields) {
+				t.Fatalf("want fields %q, got %q", tt.fields, got)
+			}
+		})
+	}
+}
+
+func TestCreatePRRequestDefaultsPriority(t *testing.T) {
+	req := createPRRequest{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1"}
+	var v validator
+	req.validate(&v)
+	if req.Priority != models.PriorityNormal {
+		t.Fatalf("want priority %q, got %q", models.PriorityNormal, req.Priority)
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		ok     bool
+		code   string
+		fields []string
+	}{
+		{name: "valid", body: `{"user_id": "u1", "is_active": true}`, ok: true},
+		{name: "unknown field", body: `{"user_id": "u1", "team": "x"}`, code: models.ErrValidation, fields: []string{"team"}},
+		{name: "wrong type", body: `{"user_id": 1}`, code: models.ErrValidation, fields: []string{"user_id"}},
+		{name: "not an object", body: `[]`, code: models.ErrValidation, fields: []string{"body"}},
+		{name: "malformed", body: `{"user_id": `, code: models.ErrInvalidRequest},
+		{name: "trailing data", body: `{"user_id": "u1"} {}`, code: models.ErrInvalidRequest},
+		{name: "empty", body: ``, code: models.ErrInvalidRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dst struct {
+				UserID   string `json:"user_id"`
+				IsActive bool   `json:"is_active"`
+			}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+
+			ok := (&Handler{}).decodeJSON(w, r, &dst)
+			if ok != tt.ok {
+				t.Fatalf("want ok %v, got %v", tt.ok, ok)
+			}
+			if ok {
+				return
+			}
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("want status 400, got %d", w.Code)
+			}
+			var resp models.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Error.Code != tt.code {
+				t.Fatalf("want code %s, got %s", tt.code, resp.Error.Code)
+			}
+			got := []string{}
+			for _, field := range resp.Error.Fields {
+				got = append(got, field.Field)
+			}
+			if len(tt.fields) > 0 && !reflect.DeepEqual(got, tt.fields) {
+				t.Fatalf("want fields %q, got %q", tt.fields, got)
+			}
+		})
+	}
+}
+
+func TestGetOverduePRsRejectsLongTeamName(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/pullRequest/overdue?team_name="+strings.Repeat("t", validate.MaxNameLength+1), nil)
+
+	(&Handler{}).GetOverduePRs(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("want status 400, got %d", w.Code)
+	}
+	var resp models.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error.Code != models.ErrValidation || len(resp.Error.Fields) != 1 || resp.Error.Fields[0].Field != "team_name" {
+		t.Fatalf("want VALIDATION_ERROR for team_name, got %+v", resp.Error)
+	}
+}
+
+func TestValidatorRepositoryUpdate(t *testing.T) {
+	str := func(s string) *string { return &s }
+	count := func(n int) *int { return &n }
+
+	tests := []struct {
+		name     string
+		update   models.RepositoryUpdate
+		fields   []string
+		strategy *string
+	}{
+		{name: "nothing", update: models.RepositoryUpdate{}, fields: []string{}},
+		{
+			name:     "every field",
+			update:   models.RepositoryUpdate{TeamName: str("backend"), ReviewerCount: count(0), Strategy: str(models.StrategyLeastLoaded)},
+			fields:   []string{},
+			strategy: str(models.StrategyLeastLoaded),
+		},
+		{name: "empty strategy", update: models.RepositoryUpdate{Strategy: str("")}, fields: []string{}, strategy: str(models.StrategyRandom)},
+		{
+			name:     "invalid values",
+			update:   models.RepositoryUpdate{TeamName: str(""), ReviewerCount: count(models.MaxReviewerCount + 1), Strategy: str("round_robin")},
+			fields:   []string{"team_name", "strategy", "reviewer_count"},
+			strategy: str("round_robin"),
+		},
+		{name: "negative count", update: models.RepositoryUpdate{ReviewerCount: count(-1)}, fields: []string{"reviewer_count"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v validator
+			v.repositoryUpdate(&tt.update)
+			if got := fieldNames(&v); !reflect.DeepEqual(got, tt.fields) {
+				t.Fatalf("want fields %q, got %q", tt.fields, got)
+			}
+			if !reflect.DeepEqual(tt.update.Strategy, tt.strategy) {
+				t.Fatalf("want strategy %v, got %v", tt.strategy, tt.update.Strategy)
+			}
+		})
+	}
+}
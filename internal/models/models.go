@@ -1,33 +1,107 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
 
 type User struct {
-	UserID   string `json:"user_id" db:"user_id"`
-	Username string `json:"username" db:"username"`
-	TeamName string `json:"team_name" db:"team_name"`
-	IsActive bool   `json:"is_active" db:"is_active"`
+	UserID    string     `json:"user_id" db:"user_id"`
+	Username  string     `json:"username" db:"username"`
+	TeamName  string     `json:"team_name" db:"team_name"`
+	IsActive  bool       `json:"is_active" db:"is_active"`
+	Teams     []string   `json:"teams" db:"-"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+type TeamMembership struct {
+	TeamName  string `json:"team_name"`
+	Role      string `json:"role"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+type ReviewLoad struct {
+	OpenReviews    int `json:"open_reviews"`
+	PendingReviews int `json:"pending_reviews"`
+}
+
+type UserProfile struct {
+	User
+	Memberships []TeamMembership `json:"memberships"`
+	Skills      []string         `json:"skills"`
+	ReviewLoad  ReviewLoad       `json:"review_load"`
+}
+
+type UserFilter struct {
+	TeamName        string
+	IncludeSubteams bool
+	IsActive        *bool
+	Skill           string
+	Search          string
+	Limit           int
+	Offset          int
+}
+
+type TeamChange struct {
+	UserID       string    `json:"user_id"`
+	FromTeamName *string   `json:"from_team_name"`
+	ToTeamName   *string   `json:"to_team_name"`
+	ChangedAt    time.Time `json:"changed_at"`
 }
 
 type TeamMember struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	IsActive bool   `json:"is_active"`
+	Role     string `json:"role,omitempty"`
 }
 
 type Team struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName       string       `json:"team_name"`
+	ParentTeamName *string      `json:"parent_team_name,omitempty"`
+	ReviewSLAHours *int         `json:"review_sla_hours,omitempty"`
+	Members        []TeamMember `json:"members"`
+	// Set by team creation and member additions: members that already belonged to another team.
+	MembersInOtherTeams []string `json:"members_in_other_teams,omitempty"`
+}
+
+type TeamNode struct {
+	TeamName       string      `json:"team_name"`
+	ParentTeamName *string     `json:"parent_team_name,omitempty"`
+	MemberCount    int         `json:"member_count"`
+	Children       []*TeamNode `json:"children"`
+}
+
+type TeamStats struct {
+	TeamName          string `json:"team_name"`
+	IncludeSubteams   bool   `json:"include_subteams"`
+	TeamCount         int    `json:"team_count"`
+	MemberCount       int    `json:"member_count"`
+	ActiveMemberCount int    `json:"active_member_count"`
+	OpenPullRequests  int    `json:"open_pull_requests"`
+	PendingReviews    int    `json:"pending_reviews"`
 }
 
 type PullRequest struct {
-	PullRequestID     string     `json:"pull_request_id" db:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name" db:"pull_request_name"`
-	AuthorID          string     `json:"author_id" db:"author_id"`
-	Status            string     `json:"status" db:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers" db:"-"`
-	CreatedAt         *time.Time `json:"createdAt,omitempty" db:"created_at"`
-	MergedAt          *time.Time `json:"mergedAt,omitempty" db:"merged_at"`
+	PullRequestID       string     `json:"pull_request_id" db:"pull_request_id"`
+	PullRequestName     string     `json:"pull_request_name" db:"pull_request_name"`
+	AuthorID            string     `json:"author_id" db:"author_id"`
+	Status              string     `json:"status" db:"status"`
+	Priority            string     `json:"priority" db:"priority"`
+	RepositoryName      string     `json:"repository_name,omitempty" db:"repository_name"`
+	ParentPullRequestID string     `json:"parent_pull_request_id,omitempty" db:"parent_pull_request_id"`
+	AssignedReviewers   []string   `json:"assigned_reviewers" db:"-"`
+	CreatedAt           *time.Time `json:"createdAt,omitempty" db:"created_at"`
+	MergedAt            *time.Time `json:"mergedAt,omitempty" db:"merged_at"`
+	Version             int        `json:"-" db:"version"`
+}
+
+type Repository struct {
+	RepositoryName string `json:"repository_name" db:"repository_name"`
+	TeamName       string `json:"team_name" db:"team_name"`
+	ReviewerCount  int    `json:"reviewer_count" db:"reviewer_count"`
+	Strategy       string `json:"strategy" db:"strategy"`
 }
 
 type PullRequestShort struct {
@@ -37,25 +111,330 @@ type PullRequestShort struct {
 	Status          string `json:"status"`
 }
 
+type StackEntry struct {
+	PullRequestShort
+	ParentPullRequestID string `json:"parent_pull_request_id,omitempty"`
+	Depth               int    `json:"depth"`
+}
+
+type UserReview struct {
+	PullRequestShort
+	Priority       string     `json:"priority"`
+	UrgencyScore   float64    `json:"urgency_score"`
+	AssignedAt     time.Time  `json:"assigned_at"`
+	RespondedAt    *time.Time `json:"responded_at,omitempty"`
+	WaitingSeconds int64      `json:"waiting_seconds"`
+}
+
+type ReviewerAssignment struct {
+	PullRequestID string     `json:"pull_request_id"`
+	UserID        string     `json:"user_id"`
+	AssignedAt    time.Time  `json:"assigned_at"`
+	RespondedAt   *time.Time `json:"responded_at,omitempty"`
+}
+
+type OverdueReviewer struct {
+	UserID         string    `json:"user_id"`
+	AssignedAt     time.Time `json:"assigned_at"`
+	WaitingSeconds int64     `json:"waiting_seconds"`
+}
+
+type ReviewerReplacement struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReplacedBy    string `json:"replaced_by"`
+}
+
+// OffboardResult reports how an offboarded user's OPEN reviews were handed over; Unassigned
+// lists PRs where no replacement was available and the reviewer slot was simply dropped.
+type OffboardResult struct {
+	User       User                  `json:"user"`
+	Reassigned []ReviewerReplacement `json:"reassigned"`
+	Unassigned []string              `json:"unassigned"`
+}
+
+// BatchCreateResult is the outcome of one item of /pullRequest/batchCreate: Status is the HTTP
+// status the item would have got from /pullRequest/create, with either PR or Error set.
+type BatchCreateResult struct {
+	PullRequestID string       `json:"pull_request_id"`
+	Status        int          `json:"status"`
+	PR            *PullRequest `json:"pr,omitempty"`
+	Error         *ErrorDetail `json:"error,omitempty"`
+}
+
+// BatchCreateResponse lists one result per requested PR, in request order.
+type BatchCreateResponse struct {
+	Results []BatchCreateResult `json:"results"`
+	Created int                 `json:"created"`
+	Failed  int                 `json:"failed"`
+}
+
+// Event types served by GET /events/stream.
+const (
+	EventPRCreated          = "pr.created"
+	EventReviewersAssigned  = "reviewers.assigned"
+	EventReviewerReassigned = "reviewer.reassigned"
+	EventPRMerged           = "pr.merged"
+	EventUserActivation     = "user.activation_changed"
+)
+
+// Event is an entry of the assignment event log. UserIDs are the users it concerns (author,
+// reviewers, ...) and TeamNames the teams they belonged to at the time; Data depends on Type.
+type Event struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	UserIDs   []string        `json:"user_ids"`
+	TeamNames []string        `json:"team_names"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// EventFilter selects the events concerning a team and/or a user; empty fields match any.
+type EventFilter struct {
+	TeamName string
+	UserID   string
+}
+
+func (f EventFilter) Matches(e *Event) bool {
+	return (f.TeamName == "" || slices.Contains(e.TeamNames, f.TeamName)) &&
+		(f.UserID == "" || slices.Contains(e.UserIDs, f.UserID))
+}
+
+// Change entities and operations of GET /changes.
+const (
+	EntityTeam           = "team"
+	EntityUser           = "user"
+	EntityTeamMembership = "team_membership"
+	EntityPullRequest    = "pull_request"
+	EntityPRReviewer     = "pr_reviewer"
+
+	OperationInsert = "insert"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+// Change is an entry of the change feed: one row of an entity table inserted, updated or
+// deleted. Key holds the row's primary key columns, Data the row after the change (null for
+// deletes) with the table's column names.
+type Change struct {
+	Cursor    int64           `json:"cursor"`
+	Entity    string          `json:"entity"`
+	Operation string          `json:"operation"`
+	Key       json.RawMessage `json:"key"`
+	Data      json.RawMessage `json:"data"`
+	ChangedAt time.Time       `json:"changed_at"`
+}
+
+type ChangesPage struct {
+	Changes []Change `json:"changes"`
+	// NextCursor is the since value for the next request: the last cursor returned, or the
+	// requested one when there are no newer changes.
+	NextCursor int64 `json:"next_cursor"`
+	HasMore    bool  `json:"has_more"`
+}
+
+type OverduePR struct {
+	PullRequestShort
+	TeamName         string            `json:"team_name"`
+	ReviewSLAHours   int               `json:"review_sla_hours"`
+	OverdueReviewers []OverdueReviewer `json:"overdue_reviewers"`
+}
+
+type PendingReview struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	UserID          string    `json:"user_id"`
+	TeamName        string    `json:"team_name"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	WaitingSeconds  int64     `json:"waiting_seconds"`
+}
+
+// TeamUpdate is a partial update of a team; nil fields and unset flags are left alone.
+type TeamUpdate struct {
+	TeamName *string
+	// SetParent applies ParentTeamName, nil detaching the team from its parent.
+	SetParent      bool
+	ParentTeamName *string
+	// SetReviewSLA applies ReviewSLAHours, nil clearing the SLA.
+	SetReviewSLA   bool
+	ReviewSLAHours *int
+}
+
+// UserUpdate is a partial update of a user; nil fields are left alone.
+type UserUpdate struct {
+	Username        *string
+	IsActive        *bool
+	PrimaryTeamName *string
+	Skills          *[]string
+}
+
+// RepositoryUpdate is a partial update of a repository; nil fields are left alone.
+type RepositoryUpdate struct {
+	TeamName      *string
+	ReviewerCount *int
+	Strategy      *string
+}
+
+// OrgChart is a bulk organisation import: teams and the users assigned to them.
+type OrgChart struct {
+	Teams []OrgTeam `json:"teams"`
+	Users []OrgUser `json:"users"`
+}
+
+type OrgTeam struct {
+	TeamName       string `json:"team_name"`
+	ParentTeamName string `json:"parent_team_name,omitempty"`
+}
+
+// TeamCycle follows parents (team name to parent team name) up from teamName and returns the
+// chain teamName, ..., teamName if it leads back to teamName, or nil.
+func TeamCycle(parents map[string]string, teamName string) []string {
+	chain := []string{teamName}
+	seen := map[string]bool{teamName: true}
+	for name := parents[teamName]; name != ""; name = parents[name] {
+		chain = append(chain, name)
+		if name == teamName {
+			return chain
+		}
+		if seen[name] {
+			return nil
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+type OrgUser struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+	IsActive bool   `json:"is_active"`
+	Role     string `json:"role,omitempty"`
+}
+
+// ImportDiff lists what an OrgChart import changes in the teams, users and team_memberships tables.
+type ImportDiff struct {
+	TeamsCreated     []OrgTeam          `json:"teams_created"`
+	TeamsReparented  []TeamParentChange `json:"teams_reparented"`
+	UsersCreated     []OrgUser          `json:"users_created"`
+	UsersUpdated     []UserChange       `json:"users_updated"`
+	MembershipsAdded []OrgUser          `json:"memberships_added"`
+	RolesChanged     []RoleChange       `json:"roles_changed"`
+}
+
+type TeamParentChange struct {
+	TeamName           string  `json:"team_name"`
+	FromParentTeamName *string `json:"from_parent_team_name"`
+	ToParentTeamName   string  `json:"to_parent_team_name"`
+}
+
+type UserChange struct {
+	UserID           string `json:"user_id"`
+	Username         string `json:"username"`
+	IsActive         bool   `json:"is_active"`
+	PreviousUsername string `json:"previous_username"`
+	PreviousIsActive bool   `json:"previous_is_active"`
+}
+
+type RoleChange struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+	FromRole string `json:"from_role"`
+	ToRole   string `json:"to_role"`
+}
+
+// IdempotentResponse is the response stored for an Idempotency-Key. Status is 0 while the first
+// request with the key is still being processed.
+type IdempotentResponse struct {
+	RequestHash string
+	Status      int
+	Header      map[string]string
+	Body        []byte
+}
+
 type ErrorResponse struct {
 	Error ErrorDetail `json:"error"`
 }
 
 type ErrorDetail struct {
-	Code    string `json:"code"`
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Details []string     `json:"details,omitempty"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
 	Message string `json:"message"`
 }
 
 const (
-	ErrTeamExists  = "TEAM_EXISTS"
-	ErrPRExists    = "PR_EXISTS"
-	ErrPRMerged    = "PR_MERGED"
-	ErrNotAssigned = "NOT_ASSIGNED"
-	ErrNoCandidate = "NO_CANDIDATE"
-	ErrNotFound    = "NOT_FOUND"
+	ErrTeamExists         = "TEAM_EXISTS"
+	ErrPRExists           = "PR_EXISTS"
+	ErrPRMerged           = "PR_MERGED"
+	ErrNotAssigned        = "NOT_ASSIGNED"
+	ErrNoCandidate        = "NO_CANDIDATE"
+	ErrNotFound           = "NOT_FOUND"
+	ErrRepositoryExists   = "REPOSITORY_EXISTS"
+	ErrParentNotMerged    = "PARENT_NOT_MERGED"
+	ErrTeamHasOpenReviews = "TEAM_HAS_OPEN_REVIEWS"
+	ErrTeamHasRepos       = "TEAM_HAS_REPOSITORIES"
+	ErrTeamCycle          = "TEAM_CYCLE"
+	ErrInvalidImport      = "INVALID_IMPORT"
+	ErrUserExists         = "USER_EXISTS"
+	ErrUserDeleted        = "USER_DELETED"
+	ErrValidation         = "VALIDATION_ERROR"
+	ErrInvalidRequest     = "INVALID_REQUEST"
+	ErrInternal           = "INTERNAL_ERROR"
+	ErrUnavailable        = "SERVICE_UNAVAILABLE"
+	ErrIdempotencyReused  = "IDEMPOTENCY_KEY_REUSED"
+	ErrIdempotencyInUse   = "IDEMPOTENCY_KEY_IN_USE"
+	ErrPreconditionFailed = "PRECONDITION_FAILED"
+	ErrCursorExpired      = "CURSOR_EXPIRED"
 )
 
 const (
 	StatusOpen   = "OPEN"
 	StatusMerged = "MERGED"
 )
+
+const (
+	PriorityLow    = "low"
+	PriorityNormal = "normal"
+	PriorityHigh   = "high"
+	PriorityHotfix = "hotfix"
+)
+
+const (
+	StrategyRandom      = "random"
+	StrategyLeastLoaded = "least_loaded"
+
+	DefaultReviewerCount = 2
+	MaxReviewerCount     = 10
+)
+
+const (
+	RoleLead     = "lead"
+	RoleMember   = "member"
+	RoleObserver = "observer"
+)
+
+func ValidRole(role string) bool {
+	switch role {
+	case RoleLead, RoleMember, RoleObserver:
+		return true
+	}
+	return false
+}
+
+func ValidStrategy(strategy string) bool {
+	return strategy == StrategyRandom || strategy == StrategyLeastLoaded
+}
+
+func ValidPriority(priority string) bool {
+	switch priority {
+	case PriorityLow, PriorityNormal, PriorityHigh, PriorityHotfix:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"pr-review-service/internal/models"
+)
+
+const (
+	KindReminder   = "REVIEW_REMINDER"
+	KindEscalation = "REVIEW_ESCALATION"
+)
+
+// Notification goes to the reviewer; escalations are addressed to the team's leads,
+// and the team's observers are copied on both kinds.
+type Notification struct {
+	Kind      string               `json:"kind"`
+	Review    models.PendingReview `json:"review"`
+	Leads     []string             `json:"leads,omitempty"`
+	Observers []string             `json:"observers,omitempty"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+func New(webhookURL string) Notifier {
+	if webhookURL == "" {
+		return LogNotifier{}
+	}
+	return &WebhookNotifier{
+		url:    webhookURL,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(ctx context.Context, n Notification) error {
+	log.Printf("%s: PR %s reviewer %s (team %s) waiting %ds, leads %v, observers %v",
+		n.Kind, n.Review.PullRequestID, n.Review.UserID, n.Review.TeamName, n.Review.WaitingSeconds, n.Leads, n.Observers)
+	return nil
+}
+
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (wn *WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wn.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := wn.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
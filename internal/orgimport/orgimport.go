@@ -0,0 +1,328 @@
+// Package orgimport parses organisation charts from CSV or YAML and imports them in bulk.
+//
+// CSV has a header row with the columns team_name, parent_team_name, user_id, username,
+// is_active and role; only team_name is required. A row without user_id just declares a team.
+//
+// YAML has two lists:
+//
+//	teams:
+//	  - team_name: backend
+//	  - team_name: payments
+//	    parent_team_name: backend
+//	users:
+//	  - user_id: u1
+//	    username: Alice
+//	    team_name: payments
+//	    role: lead
+//
+// is_active defaults to true; an empty role or parent keeps the current value.
+package orgimport
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"pr-review-service/internal/database"
+	"pr-review-service/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatYAML = "yaml"
+)
+
+// ValidationError lists every problem found in an org chart.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return models.ErrInvalidImport + ": " + strings.Join(e.Problems, "; ")
+}
+
+// FormatFromContentType maps a request Content-Type to an import format, or returns an empty string.
+func FormatFromContentType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	switch strings.TrimSpace(strings.ToLower(mediaType)) {
+	case "text/csv":
+		return FormatCSV
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return FormatYAML
+	}
+	return ""
+}
+
+// FormatFromPath guesses the import format from a file extension, or returns an empty string.
+func FormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV
+	case ".yaml", ".yml":
+		return FormatYAML
+	}
+	return ""
+}
+
+func Parse(r io.Reader, format string) (*models.OrgChart, error) {
+	switch format {
+	case FormatCSV:
+		return parseCSV(r)
+	case FormatYAML:
+		return parseYAML(r)
+	}
+	return nil, &ValidationError{Problems: []string{fmt.Sprintf("unsupported format %q, expected csv or yaml", format)}}
+}
+
+func parseCSV(r io.Reader) (*models.OrgChart, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, &ValidationError{Problems: []string{"empty CSV"}}
+	}
+	if err != nil {
+		return nil, &ValidationError{Problems: []string{err.Error()}}
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	if _, ok := columns["team_name"]; !ok {
+		return nil, &ValidationError{Problems: []string{"CSV header must contain team_name"}}
+	}
+
+	chart := &models.OrgChart{Teams: []models.OrgTeam{}, Users: []models.OrgUser{}}
+	declared := map[string]int{}
+	problems := []string{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, &ValidationError{Problems: []string{err.Error()}}
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		team := models.OrgTeam{TeamName: field("team_name"), ParentTeamName: field("parent_team_name")}
+		if i, ok := declared[team.TeamName]; !ok {
+			declared[team.TeamName] = len(chart.Teams)
+			chart.Teams = append(chart.Teams, team)
+		} else if team.ParentTeamName != "" {
+			switch chart.Teams[i].ParentTeamName {
+			case "":
+				chart.Teams[i].ParentTeamName = team.ParentTeamName
+			case team.ParentTeamName:
+			default:
+				problems = append(problems, fmt.Sprintf("line %d: team %q has conflicting parents %q and %q",
+					line, team.TeamName, chart.Teams[i].ParentTeamName, team.ParentTeamName))
+			}
+		}
+
+		if field("user_id") == "" {
+			continue
+		}
+		isActive := true
+		if value := field("is_active"); value != "" {
+			isActive, err = strconv.ParseBool(value)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("line %d: invalid is_active %q", line, value))
+			}
+		}
+		chart.Users = append(chart.Users, models.OrgUser{
+			UserID:   field("user_id"),
+			Username: field("username"),
+			TeamName: team.TeamName,
+			IsActive: isActive,
+			Role:     field("role"),
+		})
+	}
+
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+	return chart, nil
+}
+
+type yamlChart struct {
+	Teams []struct {
+		TeamName       string `yaml:"team_name"`
+		ParentTeamName string `yaml:"parent_team_name"`
+	} `yaml:"teams"`
+	Users []struct {
+		UserID   string `yaml:"user_id"`
+		Username string `yaml:"username"`
+		TeamName string `yaml:"team_name"`
+		IsActive *bool  `yaml:"is_active"`
+		Role     string `yaml:"role"`
+	} `yaml:"users"`
+}
+
+func parseYAML(r io.Reader) (*models.OrgChart, error) {
+	var doc yamlChart
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&doc); err != nil && err != io.EOF {
+		return nil, &ValidationError{Problems: []string{err.Error()}}
+	}
+
+	chart := &models.OrgChart{Teams: []models.OrgTeam{}, Users: []models.OrgUser{}}
+	for _, team := range doc.Teams {
+		chart.Teams = append(chart.Teams, models.OrgTeam{TeamName: team.TeamName, ParentTeamName: team.ParentTeamName})
+	}
+	for _, user := range doc.Users {
+		chart.Users = append(chart.Users, models.OrgUser{
+			UserID:   user.UserID,
+			Username: user.Username,
+			TeamName: user.TeamName,
+			IsActive: user.IsActive == nil || *user.IsActive,
+			Role:     user.Role,
+		})
+	}
+	return chart, nil
+}
+
+// Validate checks the chart on its own; teams referenced but not declared are returned
+// separately, since they are only valid when they already exist in the database.
+func Validate(chart *models.OrgChart) (problems, undeclared []string) {
+	declared := map[string]bool{}
+	for _, team := range chart.Teams {
+		switch {
+		case team.TeamName == "":
+			problems = append(problems, "team with empty team_name")
+		case declared[team.TeamName]:
+			problems = append(problems, fmt.Sprintf("team %q is declared twice", team.TeamName))
+		case team.ParentTeamName == team.TeamName:
+			problems = append(problems, fmt.Sprintf("team %q cannot be its own parent", team.TeamName))
+		}
+		declared[team.TeamName] = true
+	}
+
+	parents := map[string]string{}
+	for _, team := range chart.Teams {
+		if team.ParentTeamName != team.TeamName {
+			parents[team.TeamName] = team.ParentTeamName
+		}
+	}
+	inCycle := map[string]bool{}
+	for _, team := range chart.Teams {
+		if inCycle[team.TeamName] {
+			continue
+		}
+		if cycle := models.TeamCycle(parents, team.TeamName); cycle != nil {
+			for _, name := range cycle {
+				inCycle[name] = true
+			}
+			problems = append(problems, fmt.Sprintf("teams form a cycle: %s", strings.Join(cycle, " -> ")))
+		}
+	}
+
+	referenced := map[string]bool{}
+	reference := func(teamName string) {
+		if teamName != "" && !declared[teamName] && !referenced[teamName] {
+			referenced[teamName] = true
+			undeclared = append(undeclared, teamName)
+		}
+	}
+	for _, team := range chart.Teams {
+		reference(team.ParentTeamName)
+	}
+
+	teamOf := map[string]string{}
+	for _, user := range chart.Users {
+		switch {
+		case user.UserID == "":
+			problems = append(problems, "user with empty user_id")
+			continue
+		case user.Username == "":
+			problems = append(problems, fmt.Sprintf("user %q has empty username", user.UserID))
+		case user.TeamName == "":
+			problems = append(problems, fmt.Sprintf("user %q has empty team_name", user.UserID))
+		case user.Role != "" && !models.ValidRole(user.Role):
+			problems = append(problems, fmt.Sprintf("user %q has invalid role %q", user.UserID, user.Role))
+		}
+
+		if team, seen := teamOf[user.UserID]; seen {
+			if team == user.TeamName {
+				problems = append(problems, fmt.Sprintf("duplicate user_id %q", user.UserID))
+			} else {
+				problems = append(problems, fmt.Sprintf("user %q is in two teams: %q and %q", user.UserID, team, user.TeamName))
+			}
+			continue
+		}
+		teamOf[user.UserID] = user.TeamName
+		reference(user.TeamName)
+	}
+
+	return problems, undeclared
+}
+
+// Import validates the chart against itself and the existing teams, then applies it atomically.
+func Import(ctx context.Context, db *database.DB, chart *models.OrgChart, dryRun bool) (*models.ImportDiff, error) {
+	problems, undeclared := Validate(chart)
+	if len(undeclared) > 0 {
+		missing, err := db.MissingTeams(ctx, undeclared)
+		if err != nil {
+			return nil, err
+		}
+		for _, teamName := range missing {
+			problems = append(problems, fmt.Sprintf("unknown team %q", teamName))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	return db.ImportOrgChart(ctx, chart, dryRun)
+}
+
+// WriteDiff prints the diff in a compact, human-readable form.
+func WriteDiff(w io.Writer, diff *models.ImportDiff) error {
+	lines := []string{}
+	for _, team := range diff.TeamsCreated {
+		if team.ParentTeamName != "" {
+			lines = append(lines, fmt.Sprintf("+ team %s (parent %s)", team.TeamName, team.ParentTeamName))
+		} else {
+			lines = append(lines, fmt.Sprintf("+ team %s", team.TeamName))
+		}
+	}
+	for _, change := range diff.TeamsReparented {
+		from := "-"
+		if change.FromParentTeamName != nil {
+			from = *change.FromParentTeamName
+		}
+		lines = append(lines, fmt.Sprintf("~ team %s: parent %s -> %s", change.TeamName, from, change.ToParentTeamName))
+	}
+	for _, user := range diff.UsersCreated {
+		lines = append(lines, fmt.Sprintf("+ user %s (%s, active=%t)", user.UserID, user.Username, user.IsActive))
+	}
+	for _, change := range diff.UsersUpdated {
+		lines = append(lines, fmt.Sprintf("~ user %s: username %s -> %s, active %t -> %t", change.UserID,
+			change.PreviousUsername, change.Username, change.PreviousIsActive, change.IsActive))
+	}
+	for _, membership := range diff.MembershipsAdded {
+		lines = append(lines, fmt.Sprintf("+ membership %s in %s as %s", membership.UserID, membership.TeamName, membership.Role))
+	}
+	for _, change := range diff.RolesChanged {
+		lines = append(lines, fmt.Sprintf("~ role %s in %s: %s -> %s", change.UserID, change.TeamName, change.FromRole, change.ToRole))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "no changes")
+	}
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
@@ -0,0 +1,64 @@
+package orgimport
+
+import (
+	"strings"
+	"testing"
+
+	"pr-review-service/internal/models"
+)
+
+func TestValidateRejectsCycles(t *testing.T) {
+	tests := []struct {
+		name  string
+		teams []models.OrgTeam
+		cycle string
+	}{
+		{
+			name: "two teams",
+			teams: []models.OrgTeam{
+				{TeamName: "a", ParentTeamName: "b"},
+				{TeamName: "b", ParentTeamName: "a"},
+			},
+			cycle: "a -> b -> a",
+		},
+		{
+			name: "three teams",
+			teams: []models.OrgTeam{
+				{TeamName: "root"},
+				{TeamName: "a", ParentTeamName: "c"},
+				{TeamName: "b", ParentTeamName: "a"},
+				{TeamName: "c", ParentTeamName: "b"},
+				{TeamName: "leaf", ParentTeamName: "a"},
+			},
+			cycle: "a -> c -> b -> a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems, _ := Validate(&models.OrgChart{Teams: tt.teams})
+			var cycles []string
+			for _, problem := range problems {
+				if strings.Contains(problem, "cycle") {
+					cycles = append(cycles, problem)
+				}
+			}
+			if len(cycles) != 1 || !strings.HasSuffix(cycles[0], tt.cycle) {
+				t.Fatalf("want one cycle %q, got problems %q", tt.cycle, problems)
+			}
+		})
+	}
+}
+
+func TestValidateAcceptsTree(t *testing.T) {
+	problems, undeclared := Validate(&models.OrgChart{Teams: []models.OrgTeam{
+		{TeamName: "a", ParentTeamName: "existing"},
+		{TeamName: "b", ParentTeamName: "a"},
+		{TeamName: "c", ParentTeamName: "b"},
+	}})
+	if len(problems) != 0 {
+		t.Fatalf("unexpected problems %q", problems)
+	}
+	if len(undeclared) != 1 || undeclared[0] != "existing" {
+		t.Fatalf("want undeclared [existing], got %q", undeclared)
+	}
+}
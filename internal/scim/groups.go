@@ -0,0 +1,340 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/models"
+)
+
+type groupResource struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []memberRef `json:"members,omitempty"`
+	Meta        *meta       `json:"meta,omitempty"`
+}
+
+type memberRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+func toGroupResource(team *models.Team) groupResource {
+	resource := groupResource{
+		Schemas:     []string{schemaGroup},
+		ID:          team.TeamName,
+		DisplayName: team.TeamName,
+		Members:     []memberRef{},
+		Meta:        &meta{ResourceType: "Group", Location: BasePath + "/Groups/" + team.TeamName},
+	}
+	for _, member := range team.Members {
+		resource.Members = append(resource.Members, memberRef{
+			Value:   member.UserID,
+			Display: member.Username,
+			Ref:     BasePath + "/Users/" + member.UserID,
+		})
+	}
+	return resource
+}
+
+func (h *Handler) listGroups(w http.ResponseWriter, r *http.Request) {
+	startIndex, count := pagination(r)
+	withMembers := !strings.Contains(r.URL.Query().Get("excludedAttributes"), "members")
+	resources := []any{}
+
+	if filter := r.URL.Query().Get("filter"); filter != "" {
+		teamName, err := parseEqFilter(filter, "displayName")
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+			return
+		}
+		team, err := h.db.GetTeam(r.Context(), teamName, false)
+		if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+			h.respondInternalError(w, "listing SCIM groups", err)
+			return
+		}
+		if team != nil {
+			resources = append(resources, h.listedGroup(team, withMembers))
+		}
+		h.respondJSON(w, http.StatusOK, listResponse{
+			Schemas:      []string{schemaListResponse},
+			TotalResults: len(resources),
+			StartIndex:   1,
+			ItemsPerPage: len(resources),
+			Resources:    resources,
+		})
+		return
+	}
+
+	teamNames, total, err := h.db.ListTeamNames(r.Context(), count, startIndex-1)
+	if err != nil {
+		h.respondInternalError(w, "listing SCIM groups", err)
+		return
+	}
+	var teams map[string]*models.Team
+	if withMembers {
+		if teams, err = h.db.TeamsByName(r.Context(), teamNames); err != nil {
+			h.respondInternalError(w, "listing SCIM groups", err)
+			return
+		}
+	}
+	for _, teamName := range teamNames {
+		team := &models.Team{TeamName: teamName}
+		if withMembers {
+			// Skip teams deleted between listing the page and loading it.
+			if team = teams[teamName]; team == nil {
+				continue
+			}
+		}
+		resources = append(resources, h.listedGroup(team, withMembers))
+	}
+
+	h.respondJSON(w, http.StatusOK, listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func (h *Handler) listedGroup(team *models.Team, withMembers bool) groupResource {
+	resource := toGroupResource(team)
+	if !withMembers {
+		resource.Members = nil
+	}
+	return resource
+}
+
+func (h *Handler) getGroup(w http.ResponseWriter, r *http.Request, teamName string) {
+	team, err := h.db.GetTeam(r.Context(), teamName, false)
+	if err != nil {
+		h.respondGroupError(w, "getting SCIM group", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, toGroupResource(team))
+}
+
+func (h *Handler) createGroup(w http.ResponseWriter, r *http.Request) {
+	var req groupResource
+	if !h.decode(w, r, &req) {
+		return
+	}
+	if req.DisplayName == "" {
+		h.respondError(w, http.StatusBadRequest, "invalidValue", "displayName is required")
+		return
+	}
+
+	// Members must already be provisioned; their current name and activity are kept as is.
+	team := &models.Team{TeamName: req.DisplayName, Members: []models.TeamMember{}}
+	for _, ref := range req.Members {
+		user, err := h.liveUser(r, ref.Value)
+		if err != nil {
+			if errors.Is(err, apperr.ErrNotFound) {
+				h.respondError(w, http.StatusBadRequest, "invalidValue", "member "+ref.Value+" is not a provisioned user")
+				return
+			}
+			h.respondInternalError(w, "creating SCIM group", err)
+			return
+		}
+		team.Members = append(team.Members, models.TeamMember{UserID: user.UserID, Username: user.Username, IsActive: user.IsActive})
+	}
+
+	if err := h.db.CreateTeam(r.Context(), team); err != nil {
+		h.respondGroupError(w, "creating SCIM group", err)
+		return
+	}
+
+	created, err := h.db.GetTeam(r.Context(), team.TeamName, false)
+	if err != nil {
+		h.respondInternalError(w, "creating SCIM group", err)
+		return
+	}
+	resource := toGroupResource(created)
+	w.Header().Set("Location", resource.Meta.Location)
+	h.respondJSON(w, http.StatusCreated, resource)
+}
+
+func (h *Handler) replaceGroup(w http.ResponseWriter, r *http.Request, teamName string) {
+	var req groupResource
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	members := make([]string, 0, len(req.Members))
+	for _, ref := range req.Members {
+		members = append(members, ref.Value)
+	}
+	h.applyGroupChanges(w, r, teamName, req.DisplayName, func(current []string) []string { return members })
+}
+
+func (h *Handler) patchGroup(w http.ResponseWriter, r *http.Request, teamName string) {
+	var req patchRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	newName := ""
+	type memberOp struct {
+		op      string
+		userIDs []string
+		all     bool
+	}
+	memberOps := []memberOp{}
+
+	for _, op := range req.Operations {
+		kind := strings.ToLower(op.Op)
+		if kind != "add" && kind != "remove" && kind != "replace" {
+			h.respondError(w, http.StatusBadRequest, "invalidValue", "unknown operation "+op.Op)
+			return
+		}
+
+		if match := memberValueFilter.FindStringSubmatch(op.Path); match != nil && kind == "remove" {
+			memberOps = append(memberOps, memberOp{op: kind, userIDs: []string{match[1]}})
+			continue
+		}
+
+		values := map[string]json.RawMessage{}
+		if op.Path == "" {
+			if err := json.Unmarshal(op.Value, &values); err != nil {
+				h.respondError(w, http.StatusBadRequest, "invalidValue", "operation value must be an object when path is omitted")
+				return
+			}
+		} else {
+			values[op.Path] = op.Value
+		}
+
+		for path, value := range values {
+			switch strings.ToLower(path) {
+			case "displayname":
+				if kind == "remove" || json.Unmarshal(value, &newName) != nil || newName == "" {
+					h.respondError(w, http.StatusBadRequest, "invalidValue", "displayName must be a non-empty string")
+					return
+				}
+			case "members":
+				var refs []memberRef
+				if len(value) > 0 {
+					if err := json.Unmarshal(value, &refs); err != nil {
+						h.respondError(w, http.StatusBadRequest, "invalidValue", "members must be a list of {value}")
+						return
+					}
+				}
+				userIDs := make([]string, 0, len(refs))
+				for _, ref := range refs {
+					userIDs = append(userIDs, ref.Value)
+				}
+				memberOps = append(memberOps, memberOp{op: kind, userIDs: userIDs, all: kind == "remove" && len(refs) == 0})
+			default:
+				h.respondError(w, http.StatusBadRequest, "invalidPath", "unsupported path "+path)
+				return
+			}
+		}
+	}
+
+	h.applyGroupChanges(w, r, teamName, newName, func(current []string) []string {
+		members := current
+		for _, op := range memberOps {
+			switch {
+			case op.op == "replace":
+				members = op.userIDs
+			case op.op == "add":
+				members = append(members, op.userIDs...)
+			case op.all:
+				members = nil
+			default:
+				members = without(members, op.userIDs)
+			}
+		}
+		return members
+	})
+}
+
+// applyGroupChanges renames the team if needed and then syncs its members to the desired set.
+func (h *Handler) applyGroupChanges(w http.ResponseWriter, r *http.Request, teamName, newName string, desired func(current []string) []string) {
+	team, err := h.db.GetTeam(r.Context(), teamName, false)
+	if err != nil {
+		h.respondGroupError(w, "updating SCIM group", err)
+		return
+	}
+
+	if newName != "" && newName != teamName {
+		if team, err = h.db.RenameTeam(r.Context(), teamName, newName); err != nil {
+			h.respondGroupError(w, "updating SCIM group", err)
+			return
+		}
+	}
+
+	current := make([]string, 0, len(team.Members))
+	for _, member := range team.Members {
+		current = append(current, member.UserID)
+	}
+	target := desired(current)
+	add := without(target, current)
+	remove := without(current, target)
+
+	if len(add) > 0 || len(remove) > 0 {
+		if team, err = h.db.UpdateTeamMembers(r.Context(), team.TeamName, add, remove); err != nil {
+			if errors.Is(err, apperr.ErrNotFound) {
+				h.respondError(w, http.StatusBadRequest, "invalidValue", "members must be provisioned users")
+				return
+			}
+			h.respondGroupError(w, "updating SCIM group", err)
+			return
+		}
+	}
+
+	h.respondJSON(w, http.StatusOK, toGroupResource(team))
+}
+
+// deleteGroup removes the team but, like POST /team/delete without force, refuses while
+// its members still review OPEN PRs or it owns repositories.
+func (h *Handler) deleteGroup(w http.ResponseWriter, r *http.Request, teamName string) {
+	if err := h.db.DeleteTeam(r.Context(), teamName, false); err != nil {
+		h.respondGroupError(w, "deleting SCIM group", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) respondGroupError(w http.ResponseWriter, action string, err error) {
+	if errors.Is(err, apperr.ErrTeamExists) {
+		h.respondError(w, http.StatusConflict, "uniqueness", "group already exists")
+		return
+	}
+	if errors.Is(err, apperr.ErrTeamHasOpenReviews) {
+		h.respondError(w, http.StatusConflict, "", "group members still review OPEN pull requests")
+		return
+	}
+	if errors.Is(err, apperr.ErrTeamHasRepos) {
+		h.respondError(w, http.StatusConflict, "", "group owns repositories; move them to another team first")
+		return
+	}
+	if errors.Is(err, apperr.ErrNotFound) {
+		h.respondError(w, http.StatusNotFound, "", "group not found")
+		return
+	}
+	h.respondInternalError(w, action, err)
+}
+
+// without returns the unique values of list that are not in exclude, keeping their order.
+func without(list, exclude []string) []string {
+	skip := make(map[string]bool, len(exclude)+len(list))
+	for _, value := range exclude {
+		skip[value] = true
+	}
+	result := []string{}
+	for _, value := range list {
+		if !skip[value] {
+			skip[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,207 @@
+// Package scim implements a SCIM 2.0 (RFC 7643/7644) provisioning endpoint on top of the
+// users and teams tables, so an identity provider can sync joiners and leavers.
+//
+// Users map to SCIM Users: id and userName are the user_id, displayName is the username and
+// active is is_active. Teams map to SCIM Groups: id and displayName are the team_name.
+package scim
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"pr-review-service/internal/database"
+)
+
+const (
+	BasePath = "/scim/v2"
+
+	schemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+	schemaSPConfig     = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+
+	contentType = "application/scim+json"
+
+	defaultCount = 100
+	maxCount     = 200
+)
+
+type Handler struct {
+	db    *database.DB
+	token string
+}
+
+// New returns the SCIM handler; token is required as a bearer token on every request. SCIM can
+// create, rename and offboard users, so the caller must not mount it without a token.
+func New(db *database.DB, token string) *Handler {
+	return &Handler{db: db, token: token}
+}
+
+// ServeHTTP routes /scim/v2/{Users,Groups}[/{id}] and /scim/v2/ServiceProviderConfig.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || h.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="scim"`)
+		h.respondError(w, http.StatusUnauthorized, "", "invalid or missing bearer token")
+		return
+	}
+
+	resource, id, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, BasePath+"/"), "/")
+
+	switch {
+	case resource == "ServiceProviderConfig" && id == "" && r.Method == http.MethodGet:
+		h.serviceProviderConfig(w)
+	case resource == "Users" && id == "" && r.Method == http.MethodGet:
+		h.listUsers(w, r)
+	case resource == "Users" && id == "" && r.Method == http.MethodPost:
+		h.createUser(w, r)
+	case resource == "Users" && id != "" && r.Method == http.MethodGet:
+		h.getUser(w, r, id)
+	case resource == "Users" && id != "" && r.Method == http.MethodPut:
+		h.replaceUser(w, r, id)
+	case resource == "Users" && id != "" && r.Method == http.MethodPatch:
+		h.patchUser(w, r, id)
+	case resource == "Users" && id != "" && r.Method == http.MethodDelete:
+		h.deleteUser(w, r, id)
+	case resource == "Groups" && id == "" && r.Method == http.MethodGet:
+		h.listGroups(w, r)
+	case resource == "Groups" && id == "" && r.Method == http.MethodPost:
+		h.createGroup(w, r)
+	case resource == "Groups" && id != "" && r.Method == http.MethodGet:
+		h.getGroup(w, r, id)
+	case resource == "Groups" && id != "" && r.Method == http.MethodPut:
+		h.replaceGroup(w, r, id)
+	case resource == "Groups" && id != "" && r.Method == http.MethodPatch:
+		h.patchGroup(w, r, id)
+	case resource == "Groups" && id != "" && r.Method == http.MethodDelete:
+		h.deleteGroup(w, r, id)
+	case resource == "Users" || resource == "Groups" || resource == "ServiceProviderConfig":
+		h.respondError(w, http.StatusMethodNotAllowed, "", "method not allowed")
+	default:
+		h.respondError(w, http.StatusNotFound, "", "unknown SCIM resource")
+	}
+}
+
+type meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location"`
+}
+
+type listResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+type errorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+func (h *Handler) respondJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("Error encoding SCIM response: %v", err)
+	}
+}
+
+func (h *Handler) respondError(w http.ResponseWriter, status int, scimType, detail string) {
+	h.respondJSON(w, status, errorResponse{
+		Schemas:  []string{schemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+func (h *Handler) respondInternalError(w http.ResponseWriter, action string, err error) {
+	log.Printf("Error %s: %v", action, err)
+	if database.IsUnavailable(err) {
+		h.respondError(w, http.StatusServiceUnavailable, "", "Service temporarily unavailable")
+		return
+	}
+	h.respondError(w, http.StatusInternalServerError, "", "Internal server error")
+}
+
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalidSyntax", "Invalid request body")
+		return false
+	}
+	return true
+}
+
+func (h *Handler) serviceProviderConfig(w http.ResponseWriter) {
+	supported := func(ok bool) map[string]bool { return map[string]bool{"supported": ok} }
+	schemes := []any{}
+	if h.token != "" {
+		schemes = append(schemes, map[string]any{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer token",
+			"description": "Static bearer token configured via SCIM_TOKEN",
+		})
+	}
+	h.respondJSON(w, http.StatusOK, map[string]any{
+		"schemas":               []string{schemaSPConfig},
+		"patch":                 supported(true),
+		"bulk":                  map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":                map[string]any{"supported": true, "maxResults": maxCount},
+		"changePassword":        supported(false),
+		"sort":                  supported(false),
+		"etag":                  supported(false),
+		"authenticationSchemes": schemes,
+	})
+}
+
+// pagination reads the 1-based startIndex and count query parameters.
+func pagination(r *http.Request) (startIndex, count int) {
+	startIndex, err := strconv.Atoi(r.URL.Query().Get("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+	count, err = strconv.Atoi(r.URL.Query().Get("count"))
+	if err != nil || count < 0 {
+		count = defaultCount
+	}
+	if count > maxCount {
+		count = maxCount
+	}
+	return startIndex, count
+}
+
+var eqFilter = regexp.MustCompile(`^\s*(\w+)\s+eq\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+// parseEqFilter supports the only filter identity providers need for lookups: `<attribute> eq "<value>"`.
+func parseEqFilter(filter, attribute string) (string, error) {
+	match := eqFilter.FindStringSubmatch(filter)
+	if match == nil || !strings.EqualFold(match[1], attribute) {
+		return "", fmt.Errorf("only %s eq \"...\" filters are supported", attribute)
+	}
+	return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(match[2]), nil
+}
+
+// memberValueFilter matches remove paths such as members[value eq "u1"].
+var memberValueFilter = regexp.MustCompile(`^members\[\s*value\s+eq\s+"([^"]*)"\s*\]$`)
@@ -0,0 +1,320 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/models"
+	"pr-review-service/internal/validate"
+)
+
+type userResource struct {
+	Schemas     []string   `json:"schemas"`
+	ID          string     `json:"id,omitempty"`
+	UserName    string     `json:"userName"`
+	DisplayName string     `json:"displayName,omitempty"`
+	Name        *nameValue `json:"name,omitempty"`
+	Active      *bool      `json:"active,omitempty"`
+	Groups      []groupRef `json:"groups,omitempty"`
+	Meta        *meta      `json:"meta,omitempty"`
+}
+
+type nameValue struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type groupRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// username picks the best display name an identity provider sent, falling back to userName.
+func (u *userResource) username() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name != nil {
+		if u.Name.Formatted != "" {
+			return u.Name.Formatted
+		}
+		if full := strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName); full != "" {
+			return full
+		}
+	}
+	return u.UserName
+}
+
+func toUserResource(user *models.User) userResource {
+	active := user.IsActive
+	resource := userResource{
+		Schemas:     []string{schemaUser},
+		ID:          user.UserID,
+		UserName:    user.UserID,
+		DisplayName: user.Username,
+		Active:      &active,
+		Groups:      []groupRef{},
+		Meta:        &meta{ResourceType: "User", Location: BasePath + "/Users/" + user.UserID},
+	}
+	for _, teamName := range user.Teams {
+		resource.Groups = append(resource.Groups, groupRef{
+			Value:   teamName,
+			Display: teamName,
+			Ref:     BasePath + "/Groups/" + teamName,
+		})
+	}
+	return resource
+}
+
+func (h *Handler) listUsers(w http.ResponseWriter, r *http.Request) {
+	startIndex, count := pagination(r)
+	resources := []any{}
+
+	if filter := r.URL.Query().Get("filter"); filter != "" {
+		userID, err := parseEqFilter(filter, "userName")
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "invalidFilter", err.Error())
+			return
+		}
+		user, err := h.liveUser(r, userID)
+		if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+			h.respondInternalError(w, "listing SCIM users", err)
+			return
+		}
+		if user != nil {
+			resources = append(resources, toUserResource(user))
+		}
+		h.respondJSON(w, http.StatusOK, listResponse{
+			Schemas:      []string{schemaListResponse},
+			TotalResults: len(resources),
+			StartIndex:   1,
+			ItemsPerPage: len(resources),
+			Resources:    resources,
+		})
+		return
+	}
+
+	users, total, err := h.db.ListUsers(r.Context(), models.UserFilter{Limit: count, Offset: startIndex - 1})
+	if err != nil {
+		h.respondInternalError(w, "listing SCIM users", err)
+		return
+	}
+	for i := range users {
+		resources = append(resources, toUserResource(&users[i]))
+	}
+
+	h.respondJSON(w, http.StatusOK, listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// liveUser loads a user, treating offboarded users as deleted resources.
+func (h *Handler) liveUser(r *http.Request, userID string) (*models.User, error) {
+	user, err := h.db.GetUser(r.Context(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DeletedAt != nil {
+		return nil, apperr.NotFound("user")
+	}
+	return user, nil
+}
+
+func (h *Handler) getUser(w http.ResponseWriter, r *http.Request, userID string) {
+	user, err := h.liveUser(r, userID)
+	if err != nil {
+		h.respondUserError(w, "getting SCIM user", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, toUserResource(user))
+}
+
+func (h *Handler) createUser(w http.ResponseWriter, r *http.Request) {
+	var req userResource
+	if !h.decode(w, r, &req) {
+		return
+	}
+	if req.UserName == "" {
+		h.respondError(w, http.StatusBadRequest, "invalidValue", "userName is required")
+		return
+	}
+	if !h.validLengths(w, req.UserName, req.username()) {
+		return
+	}
+
+	user, err := h.db.CreateUser(r.Context(), req.UserName, req.username(), req.Active == nil || *req.Active)
+	if err != nil {
+		h.respondUserError(w, "creating SCIM user", err)
+		return
+	}
+
+	resource := toUserResource(user)
+	w.Header().Set("Location", resource.Meta.Location)
+	h.respondJSON(w, http.StatusCreated, resource)
+}
+
+func (h *Handler) replaceUser(w http.ResponseWriter, r *http.Request, userID string) {
+	var req userResource
+	if !h.decode(w, r, &req) {
+		return
+	}
+	if req.UserName != "" && req.UserName != userID {
+		h.respondError(w, http.StatusBadRequest, "mutability", "userName is immutable")
+		return
+	}
+
+	username := req.username()
+	if !h.validLengths(w, userID, username) {
+		return
+	}
+	h.applyUserChanges(w, r, userID, &username, req.Active)
+}
+
+func (h *Handler) patchUser(w http.ResponseWriter, r *http.Request, userID string) {
+	var req patchRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	var username *string
+	var active *bool
+	for _, op := range req.Operations {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+		default:
+			h.respondError(w, http.StatusBadRequest, "invalidValue", "only add and replace operations are supported for users")
+			return
+		}
+
+		values := map[string]json.RawMessage{}
+		if op.Path == "" {
+			if err := json.Unmarshal(op.Value, &values); err != nil {
+				h.respondError(w, http.StatusBadRequest, "invalidValue", "operation value must be an object when path is omitted")
+				return
+			}
+		} else {
+			values[op.Path] = op.Value
+		}
+
+		// Attributes this service does not store (emails, title, ...) are accepted and ignored.
+		for path, value := range values {
+			switch strings.ToLower(path) {
+			case "active":
+				parsed, err := parseBoolValue(value)
+				if err != nil {
+					h.respondError(w, http.StatusBadRequest, "invalidValue", "active must be a boolean")
+					return
+				}
+				active = &parsed
+			case "displayname", "name.formatted":
+				var parsed string
+				if err := json.Unmarshal(value, &parsed); err != nil {
+					h.respondError(w, http.StatusBadRequest, "invalidValue", path+" must be a string")
+					return
+				}
+				username = &parsed
+			case "username":
+				h.respondError(w, http.StatusBadRequest, "mutability", "userName is immutable")
+				return
+			}
+		}
+	}
+
+	if username != nil && !h.validLengths(w, userID, *username) {
+		return
+	}
+	h.applyUserChanges(w, r, userID, username, active)
+}
+
+// validLengths applies the limits the HTTP API has for user IDs and names, so a provisioned
+// user can be written and later addressed everywhere else; false means the error was written.
+func (h *Handler) validLengths(w http.ResponseWriter, userID, username string) bool {
+	var v validate.Validator
+	v.MaxLength("userName", userID, validate.MaxIDLength)
+	v.MaxLength("displayName", username, validate.MaxNameLength)
+	if v.Valid() {
+		return true
+	}
+	h.respondError(w, http.StatusBadRequest, "invalidValue", v.Fields[0].Field+" "+v.Fields[0].Message)
+	return false
+}
+
+// applyUserChanges writes the changed attributes; activity goes through the same
+// SetUserActive path as POST /users/setIsActive.
+func (h *Handler) applyUserChanges(w http.ResponseWriter, r *http.Request, userID string, username *string, active *bool) {
+	user, err := h.liveUser(r, userID)
+	if err != nil {
+		h.respondUserError(w, "updating SCIM user", err)
+		return
+	}
+
+	if username != nil && *username != "" && *username != user.Username {
+		if user, err = h.db.SetUsername(r.Context(), userID, *username); err != nil {
+			h.respondUserError(w, "updating SCIM user", err)
+			return
+		}
+	}
+	if active != nil && *active != user.IsActive {
+		if user, err = h.db.SetUserActive(r.Context(), userID, *active); err != nil {
+			h.respondUserError(w, "updating SCIM user", err)
+			return
+		}
+	}
+
+	h.respondJSON(w, http.StatusOK, toUserResource(user))
+}
+
+// deleteUser offboards the user like POST /users/offboard: the row is tombstoned so their PRs
+// and review history survive, and the SCIM resource is gone afterwards.
+func (h *Handler) deleteUser(w http.ResponseWriter, r *http.Request, userID string) {
+	if _, err := h.db.OffboardUser(r.Context(), userID); err != nil {
+		if errors.Is(err, apperr.ErrUserDeleted) {
+			h.respondError(w, http.StatusNotFound, "", "user not found")
+			return
+		}
+		h.respondUserError(w, "deleting SCIM user", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) respondUserError(w http.ResponseWriter, action string, err error) {
+	if errors.Is(err, apperr.ErrUserExists) {
+		h.respondError(w, http.StatusConflict, "uniqueness", "user already exists")
+		return
+	}
+	if errors.Is(err, apperr.ErrUserDeleted) {
+		h.respondError(w, http.StatusConflict, "uniqueness", "user ID belongs to an offboarded user and cannot be reused")
+		return
+	}
+	if errors.Is(err, apperr.ErrNotFound) {
+		h.respondError(w, http.StatusNotFound, "", "user not found")
+		return
+	}
+	h.respondInternalError(w, action, err)
+}
+
+// parseBoolValue accepts JSON booleans and the "True"/"False" strings some identity providers send.
+func parseBoolValue(raw json.RawMessage) (bool, error) {
+	var value bool
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value, nil
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(text)
+}
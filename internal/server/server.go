@@ -3,6 +3,7 @@ package server
 import (
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"pr-review-service/internal/handlers"
@@ -10,13 +11,18 @@ import (
 
 type Server struct {
 	handler *handlers.Handler
-	mux     *http.ServeMux
+	// mux serves the v1 and v2 routes, root additionally the mounted handlers, which are kept
+	// out of the Idempotency-Key middleware.
+	mux            *http.ServeMux
+	root           *http.ServeMux
+	idempotencyTTL time.Duration
 }
 
 func New(handler *handlers.Handler) *Server {
 	s := &Server{
 		handler: handler,
 		mux:     http.NewServeMux(),
+		root:    http.NewServeMux(),
 	}
 	s.setupRoutes()
 	return s
@@ -27,13 +33,96 @@ func (s *Server) setupRoutes() {
 
 	s.mux.HandleFunc("/team/add", s.methodFilter(http.MethodPost, s.handler.CreateTeam))
 	s.mux.HandleFunc("/team/get", s.methodFilter(http.MethodGet, s.handler.GetTeam))
+	s.mux.HandleFunc("/team/setReviewSLA", s.methodFilter(http.MethodPost, s.handler.SetTeamReviewSLA))
+	s.mux.HandleFunc("/team/addMembers", s.methodFilter(http.MethodPost, s.handler.AddTeamMembers))
+	s.mux.HandleFunc("/team/setRole", s.methodFilter(http.MethodPost, s.handler.SetMemberRole))
+	s.mux.HandleFunc("/team/removeMember", s.methodFilter(http.MethodPost, s.handler.RemoveTeamMember))
+	s.mux.HandleFunc("/team/rename", s.methodFilter(http.MethodPost, s.handler.RenameTeam))
+	s.mux.HandleFunc("/team/delete", s.methodFilter(http.MethodPost, s.handler.DeleteTeam))
+	s.mux.HandleFunc("/team/setParent", s.methodFilter(http.MethodPost, s.handler.SetTeamParent))
+	s.mux.HandleFunc("/team/tree", s.methodFilter(http.MethodGet, s.handler.GetTeamTree))
+	s.mux.HandleFunc("/team/stats", s.methodFilter(http.MethodGet, s.handler.GetTeamStats))
 
+	s.mux.HandleFunc("/repository/add", s.methodFilter(http.MethodPost, s.handler.CreateRepository))
+	s.mux.HandleFunc("/repository/get", s.methodFilter(http.MethodGet, s.handler.GetRepository))
+	s.mux.HandleFunc("/repository/update", s.methodFilter(http.MethodPost, s.handler.UpdateRepository))
+
+	s.mux.HandleFunc("/users/get", s.methodFilter(http.MethodGet, s.handler.GetUser))
+	s.mux.HandleFunc("/users/list", s.methodFilter(http.MethodGet, s.handler.ListUsers))
+	s.mux.HandleFunc("/users/setSkills", s.methodFilter(http.MethodPost, s.handler.SetUserSkills))
 	s.mux.HandleFunc("/users/setIsActive", s.methodFilter(http.MethodPost, s.handler.SetUserActive))
 	s.mux.HandleFunc("/users/getReview", s.methodFilter(http.MethodGet, s.handler.GetUserReviews))
+	s.mux.HandleFunc("/users/moveTeam", s.methodFilter(http.MethodPost, s.handler.MoveUserToTeam))
+	s.mux.HandleFunc("/users/setPrimaryTeam", s.methodFilter(http.MethodPost, s.handler.SetPrimaryTeam))
+	s.mux.HandleFunc("/users/offboard", s.methodFilter(http.MethodPost, s.handler.OffboardUser))
+	s.mux.HandleFunc("/users/teamHistory", s.methodFilter(http.MethodGet, s.handler.GetTeamHistory))
 
 	s.mux.HandleFunc("/pullRequest/create", s.methodFilter(http.MethodPost, s.handler.CreatePR))
+	s.mux.HandleFunc("/pullRequest/batchCreate", s.methodFilter(http.MethodPost, s.handler.BatchCreatePRs))
+	s.mux.HandleFunc("/pullRequest/get", s.methodFilter(http.MethodGet, s.handler.GetPR))
 	s.mux.HandleFunc("/pullRequest/merge", s.methodFilter(http.MethodPost, s.handler.MergePR))
 	s.mux.HandleFunc("/pullRequest/reassign", s.methodFilter(http.MethodPost, s.handler.ReassignReviewer))
+	s.mux.HandleFunc("/pullRequest/respond", s.methodFilter(http.MethodPost, s.handler.RespondToReview))
+	s.mux.HandleFunc("/pullRequest/overdue", s.methodFilter(http.MethodGet, s.handler.GetOverduePRs))
+
+	s.mux.HandleFunc("/admin/import", s.methodFilter(http.MethodPost, s.handler.ImportOrgChart))
+
+	s.mux.HandleFunc("/changes", s.methodFilter(http.MethodGet, s.handler.GetChanges))
+
+	s.setupV2Routes()
+}
+
+// setupV2Routes registers the resource-oriented v2 API. Method and path patterns are matched by
+// http.ServeMux itself, which also answers 405 with an Allow header for other methods.
+func (s *Server) setupV2Routes() {
+	h := s.handler
+	routes := map[string]http.HandlerFunc{
+		"POST /teams":                                 h.V2CreateTeam,
+		"GET /teams":                                  h.V2ListTeams,
+		"GET /teams/{team_name}":                      h.V2GetTeam,
+		"PATCH /teams/{team_name}":                    h.V2UpdateTeam,
+		"DELETE /teams/{team_name}":                   h.V2DeleteTeam,
+		"POST /teams/{team_name}/members":             h.V2AddTeamMembers,
+		"PATCH /teams/{team_name}/members/{user_id}":  h.V2UpdateTeamMember,
+		"DELETE /teams/{team_name}/members/{user_id}": h.V2RemoveTeamMember,
+		"GET /teams/{team_name}/tree":                 h.V2GetTeamTree,
+		"GET /teams/{team_name}/stats":                h.V2GetTeamStats,
+
+		"GET /users":                        h.V2ListUsers,
+		"GET /users/{user_id}":              h.V2GetUser,
+		"PATCH /users/{user_id}":            h.V2UpdateUser,
+		"DELETE /users/{user_id}":           h.V2DeleteUser,
+		"POST /users/{user_id}/move":        h.V2MoveUser,
+		"GET /users/{user_id}/reviews":      h.V2GetUserReviews,
+		"GET /users/{user_id}/team-history": h.V2GetTeamHistory,
+
+		"POST /pull-requests":                             h.V2CreatePR,
+		"GET /pull-requests/overdue":                      h.V2GetOverduePRs,
+		"GET /pull-requests/{pull_request_id}":            h.V2GetPR,
+		"POST /pull-requests/{pull_request_id}/merge":     h.V2MergePR,
+		"POST /pull-requests/{pull_request_id}/reassign":  h.V2ReassignReviewer,
+		"POST /pull-requests/{pull_request_id}/responses": h.V2RespondToReview,
+
+		"POST /repositories":                    h.V2CreateRepository,
+		"GET /repositories/{repository_name}":   h.V2GetRepository,
+		"PATCH /repositories/{repository_name}": h.V2UpdateRepository,
+	}
+	for pattern, handler := range routes {
+		method, path, _ := strings.Cut(pattern, " ")
+		s.mux.HandleFunc(method+" "+handlers.V2Prefix+path, handler)
+	}
+}
+
+// Mount serves a self-routing handler (e.g. SCIM) under the given path prefix. Mounted handlers
+// do their own authentication, so the Idempotency-Key middleware does not run in front of them.
+func (s *Server) Mount(prefix string, handler http.Handler) {
+	s.root.Handle(prefix, handler)
+}
+
+// EnableIdempotency stores responses to POST requests with an Idempotency-Key for ttl and
+// replays them on retries, for the v1 and v2 routes.
+func (s *Server) EnableIdempotency(ttl time.Duration) {
+	s.idempotencyTTL = ttl
 }
 
 func (s *Server) methodFilter(method string, next http.HandlerFunc) http.HandlerFunc {
@@ -52,7 +141,7 @@ func (s *Server) Start(port string) error {
 
 	server := &http.Server{
 		Addr:         addr,
-		Handler:      s.loggingMiddleware(s.mux),
+		Handler:      s.httpHandler(),
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -61,10 +150,33 @@ func (s *Server) Start(port string) error {
 	return server.ListenAndServe()
 }
 
+// httpHandler puts the middleware chain around the routes; call it once, after every Mount.
+func (s *Server) httpHandler() http.Handler {
+	var handler http.Handler = s.mux
+	if s.idempotencyTTL > 0 {
+		handler = s.handler.Idempotency(s.idempotencyTTL, handler)
+	}
+	s.root.Handle("/", handler)
+	return s.requestIDMiddleware(s.loggingMiddleware(s.root))
+}
+
 func (s *Server) loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 		next.ServeHTTP(w, r)
-		log.Printf("%s %s %s", r.Method, r.RequestURI, time.Since(start))
+		log.Printf("%s %s %s request=%s", r.Method, r.RequestURI, time.Since(start), handlers.RequestID(r.Context()))
+	})
+}
+
+// requestIDMiddleware keeps a well-formed X-Request-ID from the client or assigns a new one,
+// echoes it in the response and makes it available to handlers for error instances.
+func (s *Server) requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(handlers.RequestIDHeader)
+		if !handlers.ValidRequestID(id) {
+			id = handlers.NewRequestID()
+		}
+		w.Header().Set(handlers.RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(handlers.WithRequestID(r.Context(), id)))
 	})
 }
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"pr-review-service/internal/handlers"
+)
+
+func TestMountedHandlersSkipIdempotency(t *testing.T) {
+	// The handler has no database: reaching the Idempotency-Key middleware would panic.
+	s := New(&handlers.Handler{})
+	var served bool
+	s.Mount("/scim/v2/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = true
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	s.EnableIdempotency(time.Hour)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/scim/v2/Users", nil)
+	r.Header.Set(handlers.IdempotencyKeyHeader, "key-1")
+	s.httpHandler().ServeHTTP(w, r)
+
+	if !served || w.Code != http.StatusUnauthorized {
+		t.Fatalf("want the mounted handler's 401, got %d (served %v)", w.Code, served)
+	}
+	if w.Header().Get(handlers.IdempotentReplayedHeader) != "" {
+		t.Fatal("want no idempotent replay for a mounted handler")
+	}
+}
+
+func TestRoutesKeepIdempotency(t *testing.T) {
+	s := New(&handlers.Handler{})
+	s.EnableIdempotency(time.Hour)
+
+	// An over-long key is rejected by the middleware before the database is touched.
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/team/add", nil)
+	r.Header.Set(handlers.IdempotencyKeyHeader, strings.Repeat("k", 256))
+	s.httpHandler().ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("want status 400 from the Idempotency-Key check, got %d", w.Code)
+	}
+}
@@ -0,0 +1,78 @@
+// Package validate holds the request field rules shared by the HTTP and gRPC APIs. A Validator
+// collects every offending field so that clients can fix them in one go; each API renders the
+// collected fields in its own error format.
+package validate
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"pr-review-service/internal/models"
+)
+
+// Length limits mirror the VARCHAR columns in migrations/init.sql.
+const (
+	MaxIDLength     = 255
+	MaxNameLength   = 255
+	MaxPRNameLength = 500
+	MaxSkillLength  = 100
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+type Validator struct {
+	Fields []models.FieldError
+}
+
+func (v *Validator) Add(field, message string) {
+	v.Fields = append(v.Fields, models.FieldError{Field: field, Message: message})
+}
+
+func (v *Validator) Check(ok bool, field, message string) {
+	if !ok {
+		v.Add(field, message)
+	}
+}
+
+// Valid reports whether no field has been rejected.
+func (v *Validator) Valid() bool {
+	return len(v.Fields) == 0
+}
+
+// Required rejects an empty value and one longer than maxLength characters.
+func (v *Validator) Required(field, value string, maxLength int) {
+	if value == "" {
+		v.Add(field, "is required")
+		return
+	}
+	v.MaxLength(field, value, maxLength)
+}
+
+func (v *Validator) MaxLength(field, value string, maxLength int) {
+	if utf8.RuneCountInString(value) > maxLength {
+		v.Add(field, fmt.Sprintf("must be at most %d characters", maxLength))
+	}
+}
+
+// Unique reports every repeated value by its index, e.g. members[2].user_id.
+func (v *Validator) Unique(field, suffix string, values []string) {
+	seen := make(map[string]bool, len(values))
+	for i, value := range values {
+		if value == "" {
+			continue
+		}
+		if seen[value] {
+			v.Add(fmt.Sprintf("%s[%d]%s", field, i, suffix), "duplicate value "+value)
+		}
+		seen[value] = true
+	}
+}
+
+// Page checks a page of a list; callers substitute DefaultPageLimit for an absent limit.
+func (v *Validator) Page(limit, offset int) {
+	v.Check(limit >= 1 && limit <= MaxPageLimit, "limit", fmt.Sprintf("must be between 1 and %d", MaxPageLimit))
+	v.Check(offset >= 0, "offset", "must be a non-negative integer")
+}
@@ -0,0 +1,100 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"pr-review-service/internal/database"
+)
+
+const retentionLockName = "pr-review-service/retention"
+
+type RetentionConfig struct {
+	Interval        time.Duration
+	EventRetention  time.Duration
+	ChangeRetention time.Duration
+}
+
+// Retention purges old events, change feed entries and expired Idempotency-Key responses.
+// It runs apart from the reminder worker, so the tables stay bounded with WORKER_ENABLED=false.
+type Retention struct {
+	db  *database.DB
+	cfg RetentionConfig
+}
+
+func NewRetention(db *database.DB, cfg RetentionConfig) *Retention {
+	return &Retention{db: db, cfg: cfg}
+}
+
+func (r *Retention) Run(ctx context.Context) {
+	log.Printf("Retention started (interval %s, events %s, changes %s)",
+		r.cfg.Interval, r.cfg.EventRetention, r.cfg.ChangeRetention)
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Retention stopped")
+			return
+		case <-ticker.C:
+			r.tick(ctx)
+		}
+	}
+}
+
+func (r *Retention) tick(ctx context.Context) {
+	release, ok, err := r.db.TryLock(ctx, retentionLockName)
+	if err != nil {
+		log.Printf("Retention: error acquiring lock: %v", err)
+		return
+	}
+	if !ok {
+		return
+	}
+	defer release()
+
+	r.purgeIdempotencyKeys(ctx)
+	r.purgeEvents(ctx)
+	r.purgeChanges(ctx)
+}
+
+// purgeEvents drops events older than the retention; streams resuming from them get the rest.
+func (r *Retention) purgeEvents(ctx context.Context) {
+	n, err := r.db.PurgeEvents(ctx, r.cfg.EventRetention)
+	if err != nil {
+		log.Printf("Retention: error purging events: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Printf("Retention: purged %d old events", n)
+	}
+}
+
+// purgeChanges drops change feed entries older than the retention; consumers that were away
+// longer get CURSOR_EXPIRED and have to resync from a full dump.
+func (r *Retention) purgeChanges(ctx context.Context) {
+	n, err := r.db.PurgeChanges(ctx, r.cfg.ChangeRetention)
+	if err != nil {
+		log.Printf("Retention: error purging changes: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Printf("Retention: purged %d old changes", n)
+	}
+}
+
+// purgeIdempotencyKeys drops expired Idempotency-Key responses; expired keys are free for reuse
+// anyway, this only keeps the table small.
+func (r *Retention) purgeIdempotencyKeys(ctx context.Context) {
+	n, err := r.db.PurgeIdempotencyKeys(ctx)
+	if err != nil {
+		log.Printf("Retention: error purging idempotency keys: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Printf("Retention: purged %d expired idempotency keys", n)
+	}
+}
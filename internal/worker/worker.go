@@ -0,0 +1,149 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"pr-review-service/internal/apperr"
+	"pr-review-service/internal/database"
+	"pr-review-service/internal/notify"
+)
+
+const (
+	EscalationReassign = "reassign"
+	EscalationLead     = "lead"
+
+	lockName = "pr-review-service/reminder-worker"
+)
+
+type Config struct {
+	Interval       time.Duration
+	ReminderAfter  time.Duration
+	EscalateAfter  time.Duration
+	EscalationMode string
+}
+
+// Validate rejects settings the worker would otherwise misread: an unknown escalation mode,
+// or an escalation that fires no later than the reminder it is meant to follow.
+func (c Config) Validate() error {
+	if c.EscalationMode != EscalationReassign && c.EscalationMode != EscalationLead {
+		return fmt.Errorf("ESCALATION_MODE must be %q or %q, got %q", EscalationReassign, EscalationLead, c.EscalationMode)
+	}
+	if c.EscalateAfter <= c.ReminderAfter {
+		return fmt.Errorf("ESCALATE_AFTER (%s) must be greater than REMINDER_AFTER (%s)", c.EscalateAfter, c.ReminderAfter)
+	}
+	return nil
+}
+
+type Worker struct {
+	db       *database.DB
+	notifier notify.Notifier
+	cfg      Config
+}
+
+func New(db *database.DB, notifier notify.Notifier, cfg Config) *Worker {
+	return &Worker{db: db, notifier: notifier, cfg: cfg}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	log.Printf("Reminder worker started (interval %s, remind after %s, escalate after %s, mode %s)",
+		w.cfg.Interval, w.cfg.ReminderAfter, w.cfg.EscalateAfter, w.cfg.EscalationMode)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Reminder worker stopped")
+			return
+		case <-ticker.C:
+			w.tick(ctx)
+		}
+	}
+}
+
+func (w *Worker) tick(ctx context.Context) {
+	release, ok, err := w.db.TryLock(ctx, lockName)
+	if err != nil {
+		log.Printf("Reminder worker: error acquiring lock: %v", err)
+		return
+	}
+	if !ok {
+		return
+	}
+	defer release()
+
+	w.escalate(ctx)
+	w.remind(ctx)
+}
+
+func (w *Worker) remind(ctx context.Context) {
+	reviews, err := w.db.GetPendingReminders(ctx, w.cfg.ReminderAfter)
+	if err != nil {
+		log.Printf("Reminder worker: error loading pending reminders: %v", err)
+		return
+	}
+
+	for _, review := range reviews {
+		observers, err := w.db.GetTeamObservers(ctx, review.TeamName)
+		if err != nil {
+			log.Printf("Reminder worker: error loading observers of team %s: %v", review.TeamName, err)
+			continue
+		}
+
+		n := notify.Notification{Kind: notify.KindReminder, Review: review, Observers: observers}
+		if err := w.notifier.Notify(ctx, n); err != nil {
+			log.Printf("Reminder worker: error sending reminder for PR %s to %s: %v", review.PullRequestID, review.UserID, err)
+			continue
+		}
+		if err := w.db.MarkReminded(ctx, review.PullRequestID, review.UserID); err != nil {
+			log.Printf("Reminder worker: error marking reminder for PR %s: %v", review.PullRequestID, err)
+		}
+	}
+}
+
+func (w *Worker) escalate(ctx context.Context) {
+	reviews, err := w.db.GetPendingEscalations(ctx, w.cfg.EscalateAfter)
+	if err != nil {
+		log.Printf("Reminder worker: error loading pending escalations: %v", err)
+		return
+	}
+
+	for _, review := range reviews {
+		if w.cfg.EscalationMode == EscalationReassign {
+			_, newReviewer, err := w.db.ReassignReviewer(ctx, review.PullRequestID, review.UserID, 0)
+			if err == nil {
+				log.Printf("Reminder worker: PR %s reassigned from %s to %s", review.PullRequestID, review.UserID, newReviewer)
+				continue
+			}
+			if !errors.Is(err, apperr.ErrNoCandidate) {
+				log.Printf("Reminder worker: error reassigning PR %s from %s: %v", review.PullRequestID, review.UserID, err)
+				continue
+			}
+		}
+
+		leads, err := w.db.GetTeamLeads(ctx, review.TeamName)
+		if err != nil {
+			log.Printf("Reminder worker: error loading leads of team %s: %v", review.TeamName, err)
+			continue
+		}
+		observers, err := w.db.GetTeamObservers(ctx, review.TeamName)
+		if err != nil {
+			log.Printf("Reminder worker: error loading observers of team %s: %v", review.TeamName, err)
+			continue
+		}
+
+		n := notify.Notification{Kind: notify.KindEscalation, Review: review, Leads: leads, Observers: observers}
+		if err := w.notifier.Notify(ctx, n); err != nil {
+			log.Printf("Reminder worker: error escalating PR %s: %v", review.PullRequestID, err)
+			continue
+		}
+		if err := w.db.MarkEscalated(ctx, review.PullRequestID, review.UserID); err != nil {
+			log.Printf("Reminder worker: error marking escalation for PR %s: %v", review.PullRequestID, err)
+		}
+	}
+}
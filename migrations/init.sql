@@ -1,38 +1,293 @@
 CREATE TABLE IF NOT EXISTS teams (
     team_name VARCHAR(255) PRIMARY KEY,
+    parent_team_name VARCHAR(255) NULL REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE SET NULL,
+    review_sla_hours INTEGER NULL CHECK (review_sla_hours > 0),
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+-- Columns added after the table was first created; their constraints are (re)created at the end of the file.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS parent_team_name VARCHAR(255) NULL;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS review_sla_hours INTEGER NULL;
+
+CREATE INDEX IF NOT EXISTS idx_teams_parent_team_name ON teams(parent_team_name);
+
 CREATE TABLE IF NOT EXISTS users (
     user_id VARCHAR(255) PRIMARY KEY,
     username VARCHAR(255) NOT NULL,
-    team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
     is_active BOOLEAN NOT NULL DEFAULT true,
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    -- Offboarded users are tombstoned, never deleted, so their PRs and reviews survive and the ID is not reused.
+    deleted_at TIMESTAMP NULL
 );
 
-CREATE INDEX IF NOT EXISTS idx_users_team_name ON users(team_name);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL;
+
 CREATE INDEX IF NOT EXISTS idx_users_is_active ON users(is_active);
 
+CREATE INDEX IF NOT EXISTS idx_users_username ON users(username);
+
+CREATE TABLE IF NOT EXISTS user_skills (
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    skill VARCHAR(100) NOT NULL,
+    PRIMARY KEY (user_id, skill)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_skills_skill ON user_skills(skill);
+
+CREATE TABLE IF NOT EXISTS team_memberships (
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('lead', 'member', 'observer')),
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    joined_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, team_name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_memberships_team_name ON team_memberships(team_name);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_memberships_primary ON team_memberships(user_id) WHERE is_primary;
+
+-- Upgrade: databases created before memberships kept a single team per user in users.team_name.
+-- It becomes the user's primary membership; the column is added first so that this also runs on new databases.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS team_name VARCHAR(255) NULL;
+INSERT INTO team_memberships (user_id, team_name, role, is_primary)
+    SELECT user_id, team_name, 'member', true FROM users WHERE team_name IS NOT NULL
+    ON CONFLICT DO NOTHING;
+ALTER TABLE users DROP COLUMN IF EXISTS team_name;
+
+CREATE TABLE IF NOT EXISTS team_membership_history (
+    id SERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id),
+    from_team_name VARCHAR(255) NULL,
+    to_team_name VARCHAR(255) NULL,
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_membership_history_user_id ON team_membership_history(user_id);
+
+CREATE TABLE IF NOT EXISTS repositories (
+    repository_name VARCHAR(255) PRIMARY KEY,
+    -- RESTRICT: deleting a team must not silently take its repositories (and the PRs' links) along.
+    team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE RESTRICT,
+    reviewer_count INTEGER NOT NULL DEFAULT 2 CHECK (reviewer_count BETWEEN 0 AND 10),
+    strategy VARCHAR(20) NOT NULL DEFAULT 'random' CHECK (strategy IN ('random', 'least_loaded')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_repositories_team_name ON repositories(team_name);
+
 CREATE TABLE IF NOT EXISTS pull_requests (
     pull_request_id VARCHAR(255) PRIMARY KEY,
     pull_request_name VARCHAR(500) NOT NULL,
-    author_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    author_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE RESTRICT,
     status VARCHAR(20) NOT NULL CHECK (status IN ('OPEN', 'MERGED')),
+    priority VARCHAR(10) NOT NULL DEFAULT 'normal' CHECK (priority IN ('low', 'normal', 'high', 'hotfix')),
+    repository_name VARCHAR(255) NULL REFERENCES repositories(repository_name) ON DELETE SET NULL,
+    parent_pull_request_id VARCHAR(255) NULL REFERENCES pull_requests(pull_request_id) ON DELETE SET NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    merged_at TIMESTAMP NULL
+    merged_at TIMESTAMP NULL,
+    -- Bumped by every change of status or reviewers; served as the ETag of the PR.
+    version INTEGER NOT NULL DEFAULT 1
 );
 
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS priority VARCHAR(10) NOT NULL DEFAULT 'normal';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS repository_name VARCHAR(255) NULL;
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS parent_pull_request_id VARCHAR(255) NULL;
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
 CREATE INDEX IF NOT EXISTS idx_pull_requests_author_id ON pull_requests(author_id);
 CREATE INDEX IF NOT EXISTS idx_pull_requests_status ON pull_requests(status);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_repository_name ON pull_requests(repository_name);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_parent_pull_request_id ON pull_requests(parent_pull_request_id);
 
 CREATE TABLE IF NOT EXISTS pr_reviewers (
     id SERIAL PRIMARY KEY,
     pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
-    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE RESTRICT,
     assigned_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    responded_at TIMESTAMP NULL,
+    reminded_at TIMESTAMP NULL,
+    escalated_at TIMESTAMP NULL,
     UNIQUE(pull_request_id, user_id)
 );
 
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS responded_at TIMESTAMP NULL;
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS reminded_at TIMESTAMP NULL;
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS escalated_at TIMESTAMP NULL;
+
 CREATE INDEX IF NOT EXISTS idx_pr_reviewers_pull_request_id ON pr_reviewers(pull_request_id);
 CREATE INDEX IF NOT EXISTS idx_pr_reviewers_user_id ON pr_reviewers(user_id);
+
+-- Responses to POST requests sent with an Idempotency-Key header, replayed when the request is retried.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key VARCHAR(255) PRIMARY KEY,
+    request_hash CHAR(64) NOT NULL,
+    -- NULL while the first request with this key is still being processed.
+    response_status INTEGER NULL,
+    response_headers JSONB NULL,
+    response_body BYTEA NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+-- Log tables (events, changes) number their rows when the transaction commits: a deferred trigger takes
+-- an advisory lock, held until the commit completes, and assigns the next id. Ids therefore become
+-- visible in increasing order, so a reader paging by "id > cursor" never misses a row committed
+-- later with a smaller id. The lock is only taken at commit, when the writer no longer waits for
+-- row locks, so it cannot deadlock with them.
+CREATE OR REPLACE FUNCTION assign_log_id() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('pr-review-service/log'));
+    EXECUTE format('UPDATE %I SET id = nextval(%L) WHERE row_id = $1', TG_TABLE_NAME, TG_TABLE_NAME || '_id_seq')
+        USING NEW.row_id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Assignment events served by GET /events/stream.
+CREATE SEQUENCE IF NOT EXISTS events_id_seq;
+
+CREATE TABLE IF NOT EXISTS events (
+    row_id BIGSERIAL PRIMARY KEY,
+    -- Stream position, assigned at commit by assign_log_id.
+    id BIGINT NULL UNIQUE,
+    event_type VARCHAR(50) NOT NULL,
+    -- Users the event concerns (author, reviewers, ...) and their teams, for stream filters.
+    user_ids TEXT[] NOT NULL,
+    team_names TEXT[] NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_created_at ON events(created_at);
+
+DROP TRIGGER IF EXISTS events_assign_id ON events;
+CREATE CONSTRAINT TRIGGER events_assign_id AFTER INSERT ON events
+    DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION assign_log_id();
+
+-- Change feed served by GET /changes: every insert, update and delete of teams, users, team
+-- memberships, PRs and reviewer rows, written by triggers so that no code path can bypass it.
+CREATE SEQUENCE IF NOT EXISTS changes_id_seq;
+
+CREATE TABLE IF NOT EXISTS changes (
+    row_id BIGSERIAL PRIMARY KEY,
+    -- Feed cursor, assigned at commit by assign_log_id.
+    id BIGINT NULL UNIQUE,
+    entity VARCHAR(30) NOT NULL,
+    operation VARCHAR(10) NOT NULL CHECK (operation IN ('insert', 'update', 'delete')),
+    -- Primary key columns of the changed row.
+    entity_key JSONB NOT NULL,
+    -- The row after the change; NULL for deletes.
+    data JSONB NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_changes_changed_at ON changes(changed_at);
+
+-- Highest cursor removed by the retention purge. A consumer behind it has missed changes and
+-- must resync; the single row is guarded by the always-true key.
+CREATE TABLE IF NOT EXISTS changes_purged (
+    singleton BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (singleton),
+    purged_through BIGINT NOT NULL DEFAULT 0
+);
+
+INSERT INTO changes_purged DEFAULT VALUES ON CONFLICT DO NOTHING;
+
+DROP TRIGGER IF EXISTS changes_assign_id ON changes;
+CREATE CONSTRAINT TRIGGER changes_assign_id AFTER INSERT ON changes
+    DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION assign_log_id();
+
+-- log_change(entity, key columns...) records a row change. Updates that change nothing (e.g. an
+-- upsert with the same values) are skipped; an update of the key (team rename) is recorded as a
+-- delete of the old key followed by an insert of the new one.
+CREATE OR REPLACE FUNCTION log_change() RETURNS trigger AS $$
+DECLARE
+    old_row JSONB;
+    new_row JSONB;
+    old_key JSONB := '{}';
+    new_key JSONB := '{}';
+BEGIN
+    IF TG_OP = 'UPDATE' AND OLD IS NOT DISTINCT FROM NEW THEN
+        RETURN NULL;
+    END IF;
+    IF TG_OP <> 'INSERT' THEN
+        old_row := to_jsonb(OLD);
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        new_row := to_jsonb(NEW);
+    END IF;
+    FOR i IN 1 .. TG_NARGS - 1 LOOP
+        old_key := old_key || jsonb_build_object(TG_ARGV[i], old_row -> TG_ARGV[i]);
+        new_key := new_key || jsonb_build_object(TG_ARGV[i], new_row -> TG_ARGV[i]);
+    END LOOP;
+
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO changes (entity, operation, entity_key, data) VALUES (TG_ARGV[0], 'insert', new_key, new_row);
+    ELSIF TG_OP = 'DELETE' THEN
+        INSERT INTO changes (entity, operation, entity_key, data) VALUES (TG_ARGV[0], 'delete', old_key, NULL);
+    ELSIF old_key = new_key THEN
+        INSERT INTO changes (entity, operation, entity_key, data) VALUES (TG_ARGV[0], 'update', new_key, new_row);
+    ELSE
+        INSERT INTO changes (entity, operation, entity_key, data) VALUES (TG_ARGV[0], 'delete', old_key, NULL);
+        INSERT INTO changes (entity, operation, entity_key, data) VALUES (TG_ARGV[0], 'insert', new_key, new_row);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS teams_log_change ON teams;
+CREATE TRIGGER teams_log_change AFTER INSERT OR UPDATE OR DELETE ON teams
+    FOR EACH ROW EXECUTE FUNCTION log_change('team', 'team_name');
+
+DROP TRIGGER IF EXISTS users_log_change ON users;
+CREATE TRIGGER users_log_change AFTER INSERT OR UPDATE OR DELETE ON users
+    FOR EACH ROW EXECUTE FUNCTION log_change('user', 'user_id');
+
+DROP TRIGGER IF EXISTS team_memberships_log_change ON team_memberships;
+CREATE TRIGGER team_memberships_log_change AFTER INSERT OR UPDATE OR DELETE ON team_memberships
+    FOR EACH ROW EXECUTE FUNCTION log_change('team_membership', 'user_id', 'team_name');
+
+DROP TRIGGER IF EXISTS pull_requests_log_change ON pull_requests;
+CREATE TRIGGER pull_requests_log_change AFTER INSERT OR UPDATE OR DELETE ON pull_requests
+    FOR EACH ROW EXECUTE FUNCTION log_change('pull_request', 'pull_request_id');
+
+DROP TRIGGER IF EXISTS pr_reviewers_log_change ON pr_reviewers;
+CREATE TRIGGER pr_reviewers_log_change AFTER INSERT OR UPDATE OR DELETE ON pr_reviewers
+    FOR EACH ROW EXECUTE FUNCTION log_change('pr_reviewer', 'pull_request_id', 'user_id');
+
+-- Upgrades of databases created by an earlier version of this file. Missing columns are added
+-- after each CREATE TABLE above; here the constraints are recreated under their default names
+-- with the current definitions. Every step is idempotent, so the whole file can be applied
+-- again to an existing database.
+
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_parent_team_name_fkey;
+ALTER TABLE teams ADD CONSTRAINT teams_parent_team_name_fkey
+    FOREIGN KEY (parent_team_name) REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE SET NULL;
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_review_sla_hours_check;
+ALTER TABLE teams ADD CONSTRAINT teams_review_sla_hours_check CHECK (review_sla_hours > 0);
+
+ALTER TABLE team_memberships DROP CONSTRAINT IF EXISTS team_memberships_role_check;
+ALTER TABLE team_memberships ADD CONSTRAINT team_memberships_role_check
+    CHECK (role IN ('lead', 'member', 'observer'));
+
+ALTER TABLE repositories DROP CONSTRAINT IF EXISTS repositories_team_name_fkey;
+ALTER TABLE repositories ADD CONSTRAINT repositories_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_author_id_fkey;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_author_id_fkey
+    FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE RESTRICT;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_priority_check;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_priority_check
+    CHECK (priority IN ('low', 'normal', 'high', 'hotfix'));
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_repository_name_fkey;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_repository_name_fkey
+    FOREIGN KEY (repository_name) REFERENCES repositories(repository_name) ON DELETE SET NULL;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_parent_pull_request_id_fkey;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_parent_pull_request_id_fkey
+    FOREIGN KEY (parent_pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE SET NULL;
+
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_user_id_fkey;
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE RESTRICT;